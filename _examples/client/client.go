@@ -0,0 +1,194 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Config contains the options of the client.
+type Config struct {
+	// Host is the base url of the server, e.g. "http://127.0.0.1:8080".
+	Host string
+	// BearerToken is sent in the Authorization header of every request if it is not empty.
+	BearerToken string
+	// Header contains the additional headers of every request.
+	Header http.Header
+	// Client sends the requests, http.DefaultClient is used if it is nil.
+	Client *http.Client
+}
+
+// ListOptions contains the pagination options of the list methods.
+type ListOptions struct {
+	// Limit is the maximum number of objects to return, zero means no limit.
+	Limit int64
+	// Continue is the token returned by the previous list call to get the next page.
+	Continue string
+}
+
+// Interface has methods to work with all resources of the api.
+type Interface interface {
+	V1Namespaces() V1NamespaceInterface
+	V2Namespaces() V2NamespaceInterface
+	Secrets(namespace string) SecretInterface
+}
+
+// client implements the Interface.
+type client struct {
+	cfg        Config
+	host       string
+	httpClient *http.Client
+}
+
+// New is create a client of the api.
+func New(cfg Config) (Interface, error) {
+	host, err := url.Parse(cfg.Host)
+	if err != nil {
+		return nil, err
+	}
+	if len(host.Scheme) == 0 || len(host.Host) == 0 {
+		return nil, fmt.Errorf("host %q must be an absolute url", cfg.Host)
+	}
+	httpClient := cfg.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &client{cfg: cfg, host: strings.TrimSuffix(cfg.Host, "/"), httpClient: httpClient}, nil
+}
+
+// V1Namespaces returns the client of the V1Namespace resources.
+func (c *client) V1Namespaces() V1NamespaceInterface {
+	return &v1namespaces{client: c}
+}
+
+// V2Namespaces returns the client of the V2Namespace resources.
+func (c *client) V2Namespaces() V2NamespaceInterface {
+	return &v2namespaces{client: c}
+}
+
+// Secrets returns the client of the Secret resources.
+func (c *client) Secrets(namespace string) SecretInterface {
+	return &secrets{client: c, namespace: namespace}
+}
+
+// StatusError is returned when the server replies an error status code.
+type StatusError struct {
+	// StatusCode is the http status code of the response.
+	StatusCode int
+	// Code is the code of the response, the text of the status code.
+	Code string
+	// Message is the message of the response.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// IsNotFound returns true if err indicates that the object does not exist.
+func IsNotFound(err error) bool {
+	e, ok := err.(*StatusError)
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
+// IsConflict returns true if err indicates that the object already exists or
+// was modified concurrently.
+func IsConflict(err error) bool {
+	e, ok := err.(*StatusError)
+	return ok && e.StatusCode == http.StatusConflict
+}
+
+// commResp is the envelope of all responses.
+type commResp struct {
+	Code    string          `json:"code"`
+	Message json.RawMessage `json:"message"`
+}
+
+// do sends the request with body encoded as json and decodes the message of
+// the response into result, error responses are returned as StatusError.
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	u := c.host + path
+	if len(query) != 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for key, values := range c.cfg.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(c.cfg.BearerToken) != 0 {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.BearerToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var cr commResp
+	decodeErr := json.Unmarshal(data, &cr)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		// the errors of the router and the proxies are not json, e.g. the
+		// plain text "404 page not found"
+		if decodeErr != nil {
+			return &StatusError{StatusCode: resp.StatusCode, Code: http.StatusText(resp.StatusCode), Message: strings.TrimSpace(string(data))}
+		}
+		var message string
+		if err := json.Unmarshal(cr.Message, &message); err != nil {
+			message = string(cr.Message)
+		}
+		return &StatusError{StatusCode: resp.StatusCode, Code: cr.Code, Message: message}
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("decode the response of %s %s failed with:%v", method, path, decodeErr)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(cr.Message, result)
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	v2 "github.com/gosoon/code-generator/_examples/types/v2"
+)
+
+// SecretList is a page of the secrets returned by List.
+type SecretList struct {
+	Items []v2.Secret `json:"items"`
+	// Continue is set if there are more objects, pass it in ListOptions to get them.
+	Continue string `json:"continue,omitempty"`
+}
+
+// SecretInterface has methods to work with Secret resources.
+type SecretInterface interface {
+	Create(ctx context.Context, secretObj *v2.Secret) (*v2.Secret, error)
+	Get(ctx context.Context, name string) (*v2.Secret, error)
+	List(ctx context.Context, opts ListOptions) (*SecretList, error)
+	Update(ctx context.Context, secretObj *v2.Secret) error
+	Delete(ctx context.Context, name string) error
+}
+
+// secrets implements SecretInterface.
+type secrets struct {
+	client    *client
+	namespace string
+}
+
+// Create creates the secret and returns the object created by the server.
+func (c *secrets) Create(ctx context.Context, secretObj *v2.Secret) (*v2.Secret, error) {
+	result := &v2.Secret{}
+	if err := c.client.do(ctx, "POST", "/api/v2/namespaces/"+url.PathEscape(c.namespace)+"/secret", nil, secretObj, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Get returns the secret of name.
+func (c *secrets) Get(ctx context.Context, name string) (*v2.Secret, error) {
+	result := &v2.Secret{}
+	if err := c.client.do(ctx, "GET", "/api/v2/namespaces/"+url.PathEscape(c.namespace)+"/secret"+"/"+url.PathEscape(name), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// List returns a page of the secrets.
+func (c *secrets) List(ctx context.Context, opts ListOptions) (*SecretList, error) {
+	query := url.Values{}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.FormatInt(opts.Limit, 10))
+	}
+	if len(opts.Continue) != 0 {
+		query.Set("continue", opts.Continue)
+	}
+	result := &SecretList{}
+	if err := c.client.do(ctx, "GET", "/api/v2/namespaces/"+url.PathEscape(c.namespace)+"/secrets", query, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Update updates the secret of the name of the object.
+func (c *secrets) Update(ctx context.Context, secretObj *v2.Secret) error {
+	return c.client.do(ctx, "PUT", "/api/v2/namespaces/"+url.PathEscape(c.namespace)+"/secret"+"/"+url.PathEscape(secretObj.Name), nil, secretObj, nil)
+}
+
+// Delete deletes the secret of name.
+func (c *secrets) Delete(ctx context.Context, name string) error {
+	return c.client.do(ctx, "DELETE", "/api/v2/namespaces/"+url.PathEscape(c.namespace)+"/secret"+"/"+url.PathEscape(name), nil, nil, nil)
+}
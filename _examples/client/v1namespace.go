@@ -0,0 +1,89 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	v1 "github.com/gosoon/code-generator/_examples/types/v1"
+)
+
+// V1NamespaceList is a page of the v1namespaces returned by List.
+type V1NamespaceList struct {
+	Items []v1.Namespace `json:"items"`
+	// Continue is set if there are more objects, pass it in ListOptions to get them.
+	Continue string `json:"continue,omitempty"`
+}
+
+// V1NamespaceInterface has methods to work with V1Namespace resources.
+type V1NamespaceInterface interface {
+	Create(ctx context.Context, v1NamespaceObj *v1.Namespace) (*v1.Namespace, error)
+	Get(ctx context.Context, name string) (*v1.Namespace, error)
+	List(ctx context.Context, opts ListOptions) (*V1NamespaceList, error)
+	Update(ctx context.Context, v1NamespaceObj *v1.Namespace) error
+	Delete(ctx context.Context, name string) error
+}
+
+// v1namespaces implements V1NamespaceInterface.
+type v1namespaces struct {
+	client *client
+}
+
+// Create creates the v1Namespace and returns the object created by the server.
+func (c *v1namespaces) Create(ctx context.Context, v1NamespaceObj *v1.Namespace) (*v1.Namespace, error) {
+	result := &v1.Namespace{}
+	if err := c.client.do(ctx, "POST", "/api/v1/namespace", nil, v1NamespaceObj, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Get returns the v1Namespace of name.
+func (c *v1namespaces) Get(ctx context.Context, name string) (*v1.Namespace, error) {
+	result := &v1.Namespace{}
+	if err := c.client.do(ctx, "GET", "/api/v1/namespace"+"/"+url.PathEscape(name), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// List returns a page of the v1namespaces.
+func (c *v1namespaces) List(ctx context.Context, opts ListOptions) (*V1NamespaceList, error) {
+	query := url.Values{}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.FormatInt(opts.Limit, 10))
+	}
+	if len(opts.Continue) != 0 {
+		query.Set("continue", opts.Continue)
+	}
+	result := &V1NamespaceList{}
+	if err := c.client.do(ctx, "GET", "/api/v1/namespaces", query, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Update updates the v1Namespace of the name of the object.
+func (c *v1namespaces) Update(ctx context.Context, v1NamespaceObj *v1.Namespace) error {
+	return c.client.do(ctx, "PUT", "/api/v1/namespace"+"/"+url.PathEscape(v1NamespaceObj.Name), nil, v1NamespaceObj, nil)
+}
+
+// Delete deletes the v1Namespace of name.
+func (c *v1namespaces) Delete(ctx context.Context, name string) error {
+	return c.client.do(ctx, "DELETE", "/api/v1/namespace"+"/"+url.PathEscape(name), nil, nil, nil)
+}
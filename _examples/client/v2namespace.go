@@ -0,0 +1,89 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	v2 "github.com/gosoon/code-generator/_examples/types/v2"
+)
+
+// V2NamespaceList is a page of the v2namespaces returned by List.
+type V2NamespaceList struct {
+	Items []v2.Namespace `json:"items"`
+	// Continue is set if there are more objects, pass it in ListOptions to get them.
+	Continue string `json:"continue,omitempty"`
+}
+
+// V2NamespaceInterface has methods to work with V2Namespace resources.
+type V2NamespaceInterface interface {
+	Create(ctx context.Context, v2NamespaceObj *v2.Namespace) (*v2.Namespace, error)
+	Get(ctx context.Context, name string) (*v2.Namespace, error)
+	List(ctx context.Context, opts ListOptions) (*V2NamespaceList, error)
+	Update(ctx context.Context, v2NamespaceObj *v2.Namespace) error
+	Delete(ctx context.Context, name string) error
+}
+
+// v2namespaces implements V2NamespaceInterface.
+type v2namespaces struct {
+	client *client
+}
+
+// Create creates the v2Namespace and returns the object created by the server.
+func (c *v2namespaces) Create(ctx context.Context, v2NamespaceObj *v2.Namespace) (*v2.Namespace, error) {
+	result := &v2.Namespace{}
+	if err := c.client.do(ctx, "POST", "/api/v2/namespace", nil, v2NamespaceObj, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Get returns the v2Namespace of name.
+func (c *v2namespaces) Get(ctx context.Context, name string) (*v2.Namespace, error) {
+	result := &v2.Namespace{}
+	if err := c.client.do(ctx, "GET", "/api/v2/namespace"+"/"+url.PathEscape(name), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// List returns a page of the v2namespaces.
+func (c *v2namespaces) List(ctx context.Context, opts ListOptions) (*V2NamespaceList, error) {
+	query := url.Values{}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.FormatInt(opts.Limit, 10))
+	}
+	if len(opts.Continue) != 0 {
+		query.Set("continue", opts.Continue)
+	}
+	result := &V2NamespaceList{}
+	if err := c.client.do(ctx, "GET", "/api/v2/namespaces", query, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Update updates the v2Namespace of the name of the object.
+func (c *v2namespaces) Update(ctx context.Context, v2NamespaceObj *v2.Namespace) error {
+	return c.client.do(ctx, "PUT", "/api/v2/namespace"+"/"+url.PathEscape(v2NamespaceObj.Name), nil, v2NamespaceObj, nil)
+}
+
+// Delete deletes the v2Namespace of name.
+func (c *v2namespaces) Delete(ctx context.Context, name string) error {
+	return c.client.do(ctx, "DELETE", "/api/v2/namespace"+"/"+url.PathEscape(name), nil, nil, nil)
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package controller
+
+import (
+	"fmt"
+	"mime"
+
+	jsonpatch "github.com/evanphx/json-patch"
+)
+
+const (
+	// MergePatchType is the content type of a JSON Merge Patch (RFC 7386).
+	MergePatchType = "application/merge-patch+json"
+	// JSONPatchType is the content type of a JSON Patch (RFC 6902).
+	JSONPatchType = "application/json-patch+json"
+)
+
+// ErrUnsupportedPatchType is returned by ApplyPatch for unknown content types.
+var ErrUnsupportedPatchType = fmt.Errorf("unsupported patch content type, use %q or %q", MergePatchType, JSONPatchType)
+
+// ApplyPatch applies the patch to the original JSON document according to the
+// content type of the request.
+func ApplyPatch(contentType string, original, patch []byte) ([]byte, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedPatchType
+	}
+
+	switch mediaType {
+	case MergePatchType:
+		return jsonpatch.MergePatch(original, patch)
+	case JSONPatchType:
+		p, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, err
+		}
+		return p.Apply(original)
+	}
+	return nil, ErrUnsupportedPatchType
+}
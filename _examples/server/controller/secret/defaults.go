@@ -0,0 +1,24 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package secret
+
+import (
+	v2 "github.com/gosoon/code-generator/_examples/types/v2"
+)
+
+// SetDefaults_Secret sets the fields of obj which are not set to the values of
+// their +default tags, the fields of the nested structs included.
+func SetDefaults_Secret(obj *v2.Secret) {}
@@ -14,4 +14,4 @@
  * limitations under the License.
  */
 // This package has the automatically generated type controller.
-package namespace
+package secret
@@ -0,0 +1,230 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gosoon/code-generator/_examples/server/controller"
+	"github.com/gosoon/code-generator/_examples/server/middleware"
+	v2 "github.com/gosoon/code-generator/_examples/types/v2"
+)
+
+// secret implements the controller interface.
+type secret struct {
+	opt *controller.Options
+}
+
+// New is create a secret object.
+func New(opt *controller.Options) controller.Controller {
+	return &secret{opt: opt}
+}
+
+// Register is register the routes to router
+func (c *secret) Register(router *mux.Router) {
+	router = router.PathPrefix("/api/v2").Subrouter()
+
+	// create
+	router.Methods("POST").Path("/namespaces/{namespace}/secret").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.createSecret))))
+
+	// get
+	router.Methods("GET").Path("/namespaces/{namespace}/secret/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.getSecret))))
+
+	// list
+	router.Methods("GET").Path("/namespaces/{namespace}/secrets").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.listSecret))))
+
+	// update
+	router.Methods("PUT").Path("/namespaces/{namespace}/secret/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.updateSecret))))
+
+	// delete
+	router.Methods("DELETE").Path("/namespaces/{namespace}/secret/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.deleteSecret))))
+
+	// patch
+	router.Methods("PATCH").Path("/namespaces/{namespace}/secret/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.patchSecret))))
+}
+
+// createSecret
+func (c *secret) createSecret(w http.ResponseWriter, r *http.Request) {
+	namespace := mux.Vars(r)["namespace"]
+	secretObj := &v2.Secret{}
+	err := json.NewDecoder(r.Body).Decode(secretObj)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+	SetDefaults_Secret(secretObj)
+	if fieldErrors := ValidateSecret(secretObj); len(fieldErrors) != 0 {
+		controller.UnprocessableEntity(w, r, fieldErrors)
+		return
+	}
+
+	created, err := c.opt.Service.CreateSecret(r.Context(), namespace, secretObj)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	location := "/api/v2/namespaces/" + namespace + "/secret" + "/" + created.Name
+	controller.Created(w, r, location, created)
+}
+
+// getSecret
+func (c *secret) getSecret(w http.ResponseWriter, r *http.Request) {
+	namespace := mux.Vars(r)["namespace"]
+	name := mux.Vars(r)["name"]
+	secretObj, err := c.opt.Service.GetSecret(r.Context(), namespace, name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Response(w, r, http.StatusOK, secretObj)
+}
+
+// listSecret
+func (c *secret) listSecret(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("watch") == "true" {
+		c.watchSecret(w, r)
+		return
+	}
+	opts, err := controller.ListOptions(r)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	secretList, err := c.opt.Service.ListSecret(r.Context(), mux.Vars(r)["namespace"], opts)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Response(w, r, http.StatusOK, secretList)
+}
+
+// watchSecret
+func (c *secret) watchSecret(w http.ResponseWriter, r *http.Request) {
+	// the service stops sending events when the client disconnects
+	events, err := c.opt.Service.WatchSecret(r.Context(), mux.Vars(r)["namespace"])
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Watch(w, r, events)
+}
+
+// updateSecret
+func (c *secret) updateSecret(w http.ResponseWriter, r *http.Request) {
+	namespace := mux.Vars(r)["namespace"]
+	name := mux.Vars(r)["name"]
+	secretObj := &v2.Secret{}
+	err := json.NewDecoder(r.Body).Decode(secretObj)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+	if len(secretObj.Name) == 0 {
+		secretObj.Name = name
+	}
+	if secretObj.Name != name {
+		controller.BadRequest(w, r, fmt.Errorf("name %q in body does not match name %q in path", secretObj.Name, name))
+		return
+	}
+	if fieldErrors := ValidateSecret(secretObj); len(fieldErrors) != 0 {
+		controller.UnprocessableEntity(w, r, fieldErrors)
+		return
+	}
+
+	err = c.opt.Service.UpdateSecret(r.Context(), namespace, secretObj)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.OK(w, r, "success")
+}
+
+// deleteSecret
+func (c *secret) deleteSecret(w http.ResponseWriter, r *http.Request) {
+	namespace := mux.Vars(r)["namespace"]
+	name := mux.Vars(r)["name"]
+	err := c.opt.Service.DeleteSecret(r.Context(), namespace, name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.OK(w, r, "success")
+}
+
+// patchSecret
+func (c *secret) patchSecret(w http.ResponseWriter, r *http.Request) {
+	namespace := mux.Vars(r)["namespace"]
+	name := mux.Vars(r)["name"]
+	patch, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	// get object
+	current, err := c.opt.Service.GetSecret(r.Context(), namespace, name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	original, err := json.Marshal(current)
+	if err != nil {
+		controller.InternalError(w, r, err)
+		return
+	}
+
+	patched, err := controller.ApplyPatch(r.Header.Get("Content-Type"), original, patch)
+	if err == controller.ErrUnsupportedPatchType {
+		controller.UnsupportedMediaType(w, r, err)
+		return
+	}
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	secretObj := &v2.Secret{}
+	err = json.Unmarshal(patched, secretObj)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+	// the object is addressed by the path, a patch can not rename it
+	secretObj.Name = name
+	if fieldErrors := ValidateSecret(secretObj); len(fieldErrors) != 0 {
+		controller.UnprocessableEntity(w, r, fieldErrors)
+		return
+	}
+
+	// update object
+	err = c.opt.Service.UpdateSecret(r.Context(), namespace, secretObj)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.OK(w, r, "success")
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/gosoon/code-generator/_examples/server/errors"
 	"k8s.io/klog"
 )
 
@@ -32,6 +33,12 @@ func OK(w http.ResponseWriter, r *http.Request, message string) {
 	Response(w, r, http.StatusOK, message)
 }
 
+// Created reply the created object with the location of the object
+func Created(w http.ResponseWriter, r *http.Request, location string, obj interface{}) {
+	w.Header().Set("Location", location)
+	Response(w, r, http.StatusCreated, obj)
+}
+
 // ResourceNotFound will return an error message indicating that the resource is not exist
 func ResourceNotFound(w http.ResponseWriter, r *http.Request, message string) {
 	Response(w, r, http.StatusNotFound, message)
@@ -72,6 +79,16 @@ func NotAcceptable(w http.ResponseWriter, r *http.Request, err error) {
 	Response(w, r, http.StatusNotAcceptable, err.Error())
 }
 
+// UnprocessableEntity will return the field errors of an object which is not valid
+func UnprocessableEntity(w http.ResponseWriter, r *http.Request, fields errors.FieldErrors) {
+	Response(w, r, http.StatusUnprocessableEntity, fields)
+}
+
+// UnsupportedMediaType will return an error message indicating that the content type of the request is not supported
+func UnsupportedMediaType(w http.ResponseWriter, r *http.Request, err error) {
+	Response(w, r, http.StatusUnsupportedMediaType, err.Error())
+}
+
 // Response : http response func (no return http code)
 func Response(w http.ResponseWriter, r *http.Request, httpCode int, message interface{}) {
 	resp := commResp{
@@ -92,3 +109,24 @@ func Response(w http.ResponseWriter, r *http.Request, httpCode int, message inte
 		w.Write(jsonByte)
 	}
 }
+
+// ServiceError replies the error returned by the service with the http status code of its reason,
+// errors which are not returned by the errors package are internal errors.
+func ServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch errors.ReasonForError(err) {
+	case errors.ReasonNotFound:
+		ResourceNotFound(w, r, err.Error())
+	case errors.ReasonAlreadyExists, errors.ReasonConflict:
+		Conflict(w, r, err)
+	case errors.ReasonInvalid:
+		BadRequest(w, r, err)
+	case errors.ReasonForbidden:
+		Forbidden(w, r, err)
+	case errors.ReasonUnavailable:
+		ServiceUnavailable(w, r, err)
+	case errors.ReasonUnprocessable:
+		UnprocessableEntity(w, r, err.(*errors.StatusError).Fields)
+	default:
+		InternalError(w, r, err)
+	}
+}
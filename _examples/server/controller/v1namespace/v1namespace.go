@@ -0,0 +1,225 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package v1namespace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gosoon/code-generator/_examples/server/controller"
+	"github.com/gosoon/code-generator/_examples/server/middleware"
+	v1 "github.com/gosoon/code-generator/_examples/types/v1"
+)
+
+// v1Namespace implements the controller interface.
+type v1Namespace struct {
+	opt *controller.Options
+}
+
+// New is create a v1Namespace object.
+func New(opt *controller.Options) controller.Controller {
+	return &v1Namespace{opt: opt}
+}
+
+// Register is register the routes to router
+func (c *v1Namespace) Register(router *mux.Router) {
+	router = router.PathPrefix("/api/v1").Subrouter()
+
+	// create
+	router.Methods("POST").Path("/namespace").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.createV1Namespace))))
+
+	// get
+	router.Methods("GET").Path("/namespace/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.getV1Namespace))))
+
+	// list
+	router.Methods("GET").Path("/namespaces").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.listV1Namespace))))
+
+	// update
+	router.Methods("PUT").Path("/namespace/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.updateV1Namespace))))
+
+	// delete
+	router.Methods("DELETE").Path("/namespace/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.deleteV1Namespace))))
+
+	// patch
+	router.Methods("PATCH").Path("/namespace/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.patchV1Namespace))))
+}
+
+// createV1Namespace
+func (c *v1Namespace) createV1Namespace(w http.ResponseWriter, r *http.Request) {
+	v1NamespaceObj := &v1.Namespace{}
+	err := json.NewDecoder(r.Body).Decode(v1NamespaceObj)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+	SetDefaults_V1Namespace(v1NamespaceObj)
+	if fieldErrors := ValidateV1Namespace(v1NamespaceObj); len(fieldErrors) != 0 {
+		controller.UnprocessableEntity(w, r, fieldErrors)
+		return
+	}
+
+	created, err := c.opt.Service.CreateV1Namespace(r.Context(), v1NamespaceObj)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	location := "/api/v1/namespace" + "/" + created.Name
+	controller.Created(w, r, location, created)
+}
+
+// getV1Namespace
+func (c *v1Namespace) getV1Namespace(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	v1NamespaceObj, err := c.opt.Service.GetV1Namespace(r.Context(), name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Response(w, r, http.StatusOK, v1NamespaceObj)
+}
+
+// listV1Namespace
+func (c *v1Namespace) listV1Namespace(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("watch") == "true" {
+		c.watchV1Namespace(w, r)
+		return
+	}
+	opts, err := controller.ListOptions(r)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	v1NamespaceList, err := c.opt.Service.ListV1Namespace(r.Context(), opts)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Response(w, r, http.StatusOK, v1NamespaceList)
+}
+
+// watchV1Namespace
+func (c *v1Namespace) watchV1Namespace(w http.ResponseWriter, r *http.Request) {
+	// the service stops sending events when the client disconnects
+	events, err := c.opt.Service.WatchV1Namespace(r.Context())
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Watch(w, r, events)
+}
+
+// updateV1Namespace
+func (c *v1Namespace) updateV1Namespace(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	v1NamespaceObj := &v1.Namespace{}
+	err := json.NewDecoder(r.Body).Decode(v1NamespaceObj)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+	if len(v1NamespaceObj.Name) == 0 {
+		v1NamespaceObj.Name = name
+	}
+	if v1NamespaceObj.Name != name {
+		controller.BadRequest(w, r, fmt.Errorf("name %q in body does not match name %q in path", v1NamespaceObj.Name, name))
+		return
+	}
+	if fieldErrors := ValidateV1Namespace(v1NamespaceObj); len(fieldErrors) != 0 {
+		controller.UnprocessableEntity(w, r, fieldErrors)
+		return
+	}
+
+	err = c.opt.Service.UpdateV1Namespace(r.Context(), v1NamespaceObj)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.OK(w, r, "success")
+}
+
+// deleteV1Namespace
+func (c *v1Namespace) deleteV1Namespace(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	err := c.opt.Service.DeleteV1Namespace(r.Context(), name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.OK(w, r, "success")
+}
+
+// patchV1Namespace
+func (c *v1Namespace) patchV1Namespace(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	patch, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	// get object
+	current, err := c.opt.Service.GetV1Namespace(r.Context(), name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	original, err := json.Marshal(current)
+	if err != nil {
+		controller.InternalError(w, r, err)
+		return
+	}
+
+	patched, err := controller.ApplyPatch(r.Header.Get("Content-Type"), original, patch)
+	if err == controller.ErrUnsupportedPatchType {
+		controller.UnsupportedMediaType(w, r, err)
+		return
+	}
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	v1NamespaceObj := &v1.Namespace{}
+	err = json.Unmarshal(patched, v1NamespaceObj)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+	// the object is addressed by the path, a patch can not rename it
+	v1NamespaceObj.Name = name
+	if fieldErrors := ValidateV1Namespace(v1NamespaceObj); len(fieldErrors) != 0 {
+		controller.UnprocessableEntity(w, r, fieldErrors)
+		return
+	}
+
+	// update object
+	err = c.opt.Service.UpdateV1Namespace(r.Context(), v1NamespaceObj)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.OK(w, r, "success")
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package v2namespace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gosoon/code-generator/_examples/server/controller"
+	"github.com/gosoon/code-generator/_examples/server/middleware"
+	v2 "github.com/gosoon/code-generator/_examples/types/v2"
+)
+
+// v2Namespace implements the controller interface.
+type v2Namespace struct {
+	opt *controller.Options
+}
+
+// New is create a v2Namespace object.
+func New(opt *controller.Options) controller.Controller {
+	return &v2Namespace{opt: opt}
+}
+
+// Register is register the routes to router
+func (c *v2Namespace) Register(router *mux.Router) {
+	router = router.PathPrefix("/api/v2").Subrouter()
+
+	// create
+	router.Methods("POST").Path("/namespace").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.createV2Namespace))))
+
+	// get
+	router.Methods("GET").Path("/namespace/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.getV2Namespace))))
+
+	// list
+	router.Methods("GET").Path("/namespaces").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.listV2Namespace))))
+
+	// update
+	router.Methods("PUT").Path("/namespace/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.updateV2Namespace))))
+
+	// delete
+	router.Methods("DELETE").Path("/namespace/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.deleteV2Namespace))))
+
+	// patch
+	router.Methods("PATCH").Path("/namespace/{name}").HandlerFunc(
+		middleware.Authenticate(http.HandlerFunc((c.patchV2Namespace))))
+}
+
+// createV2Namespace
+func (c *v2Namespace) createV2Namespace(w http.ResponseWriter, r *http.Request) {
+	v2NamespaceObj := &v2.Namespace{}
+	err := json.NewDecoder(r.Body).Decode(v2NamespaceObj)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+	SetDefaults_V2Namespace(v2NamespaceObj)
+	if fieldErrors := ValidateV2Namespace(v2NamespaceObj); len(fieldErrors) != 0 {
+		controller.UnprocessableEntity(w, r, fieldErrors)
+		return
+	}
+
+	created, err := c.opt.Service.CreateV2Namespace(r.Context(), v2NamespaceObj)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	location := "/api/v2/namespace" + "/" + created.Name
+	controller.Created(w, r, location, created)
+}
+
+// getV2Namespace
+func (c *v2Namespace) getV2Namespace(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	v2NamespaceObj, err := c.opt.Service.GetV2Namespace(r.Context(), name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Response(w, r, http.StatusOK, v2NamespaceObj)
+}
+
+// listV2Namespace
+func (c *v2Namespace) listV2Namespace(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("watch") == "true" {
+		c.watchV2Namespace(w, r)
+		return
+	}
+	opts, err := controller.ListOptions(r)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	v2NamespaceList, err := c.opt.Service.ListV2Namespace(r.Context(), opts)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Response(w, r, http.StatusOK, v2NamespaceList)
+}
+
+// watchV2Namespace
+func (c *v2Namespace) watchV2Namespace(w http.ResponseWriter, r *http.Request) {
+	// the service stops sending events when the client disconnects
+	events, err := c.opt.Service.WatchV2Namespace(r.Context())
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Watch(w, r, events)
+}
+
+// updateV2Namespace
+func (c *v2Namespace) updateV2Namespace(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	v2NamespaceObj := &v2.Namespace{}
+	err := json.NewDecoder(r.Body).Decode(v2NamespaceObj)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+	if len(v2NamespaceObj.Name) == 0 {
+		v2NamespaceObj.Name = name
+	}
+	if v2NamespaceObj.Name != name {
+		controller.BadRequest(w, r, fmt.Errorf("name %q in body does not match name %q in path", v2NamespaceObj.Name, name))
+		return
+	}
+	if fieldErrors := ValidateV2Namespace(v2NamespaceObj); len(fieldErrors) != 0 {
+		controller.UnprocessableEntity(w, r, fieldErrors)
+		return
+	}
+
+	err = c.opt.Service.UpdateV2Namespace(r.Context(), v2NamespaceObj)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.OK(w, r, "success")
+}
+
+// deleteV2Namespace
+func (c *v2Namespace) deleteV2Namespace(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	err := c.opt.Service.DeleteV2Namespace(r.Context(), name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.OK(w, r, "success")
+}
+
+// patchV2Namespace
+func (c *v2Namespace) patchV2Namespace(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	patch, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	// get object
+	current, err := c.opt.Service.GetV2Namespace(r.Context(), name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	original, err := json.Marshal(current)
+	if err != nil {
+		controller.InternalError(w, r, err)
+		return
+	}
+
+	patched, err := controller.ApplyPatch(r.Header.Get("Content-Type"), original, patch)
+	if err == controller.ErrUnsupportedPatchType {
+		controller.UnsupportedMediaType(w, r, err)
+		return
+	}
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	v2NamespaceObj := &v2.Namespace{}
+	err = json.Unmarshal(patched, v2NamespaceObj)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+	// the object is addressed by the path, a patch can not rename it
+	v2NamespaceObj.Name = name
+	if fieldErrors := ValidateV2Namespace(v2NamespaceObj); len(fieldErrors) != 0 {
+		controller.UnprocessableEntity(w, r, fieldErrors)
+		return
+	}
+
+	// update object
+	err = c.opt.Service.UpdateV2Namespace(r.Context(), v2NamespaceObj)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.OK(w, r, "success")
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package v2namespace
+
+import (
+	"github.com/gosoon/code-generator/_examples/server/errors"
+	v2 "github.com/gosoon/code-generator/_examples/types/v2"
+)
+
+// ValidateV2Namespace returns the errors of the fields of obj which do not
+// match their +validation tags, the fields are identified by their json path.
+func ValidateV2Namespace(obj *v2.Namespace) errors.FieldErrors {
+	return validateV2Namespace(obj, "")
+}
+
+// validateV2Namespace validates the fields of obj, path is the json path of obj.
+func validateV2Namespace(obj *v2.Namespace, path string) errors.FieldErrors {
+	return nil
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reason is the reason of a StatusError, the controller replies the http
+// status code of the reason.
+type Reason string
+
+const (
+	// ReasonNotFound means the object does not exist, replied with 404.
+	ReasonNotFound Reason = "NotFound"
+	// ReasonAlreadyExists means the object to create already exists, replied with 409.
+	ReasonAlreadyExists Reason = "AlreadyExists"
+	// ReasonConflict means the object was modified concurrently, replied with 409.
+	ReasonConflict Reason = "Conflict"
+	// ReasonInvalid means the object is not valid, replied with 400.
+	ReasonInvalid Reason = "Invalid"
+	// ReasonForbidden means the operation is not allowed, replied with 403.
+	ReasonForbidden Reason = "Forbidden"
+	// ReasonUnavailable means the backend of the service is not available, replied with 503.
+	ReasonUnavailable Reason = "Unavailable"
+	// ReasonUnprocessable means the fields of the object are not valid, replied
+	// with 422 and the field errors.
+	ReasonUnprocessable Reason = "Unprocessable"
+)
+
+// StatusError is an error returned by the service with a reason.
+type StatusError struct {
+	Reason  Reason
+	Message string
+	// Fields are the field errors of an Unprocessable error.
+	Fields FieldErrors
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+// FieldError is an error of a field of an object, the field is the json path
+// of the field, e.g. "spec.ports[0].name".
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// FieldErrors is a list of field errors.
+type FieldErrors []FieldError
+
+// Error implements the error interface.
+func (e FieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldError := range e {
+		messages = append(messages, fieldError.Error())
+	}
+	return strings.Join(messages, ", ")
+}
+
+// NewNotFound returns an error indicating that the object of kind does not exist.
+func NewNotFound(kind, name string) *StatusError {
+	return &StatusError{Reason: ReasonNotFound, Message: fmt.Sprintf("%s %q not found", kind, name)}
+}
+
+// NewAlreadyExists returns an error indicating that the object of kind already exists.
+func NewAlreadyExists(kind, name string) *StatusError {
+	return &StatusError{Reason: ReasonAlreadyExists, Message: fmt.Sprintf("%s %q already exists", kind, name)}
+}
+
+// NewConflict returns an error indicating that the object of kind can not be
+// modified because of a concurrent modification.
+func NewConflict(kind, name string, err error) *StatusError {
+	return &StatusError{Reason: ReasonConflict, Message: fmt.Sprintf("operation cannot be fulfilled on %s %q: %v", kind, name, err)}
+}
+
+// NewInvalid returns an error indicating that the object of kind is not valid.
+func NewInvalid(kind, name string, err error) *StatusError {
+	return &StatusError{Reason: ReasonInvalid, Message: fmt.Sprintf("%s %q is invalid: %v", kind, name, err)}
+}
+
+// NewForbidden returns an error indicating that the operation on the object of kind is not allowed.
+func NewForbidden(kind, name string, err error) *StatusError {
+	return &StatusError{Reason: ReasonForbidden, Message: fmt.Sprintf("%s %q is forbidden: %v", kind, name, err)}
+}
+
+// NewUnavailable returns an error indicating that the backend of the service is not available.
+func NewUnavailable(message string) *StatusError {
+	return &StatusError{Reason: ReasonUnavailable, Message: message}
+}
+
+// NewUnprocessable returns an error indicating that the fields of the object of kind are not valid.
+func NewUnprocessable(kind, name string, fields FieldErrors) *StatusError {
+	return &StatusError{Reason: ReasonUnprocessable, Message: fmt.Sprintf("%s %q is invalid: %v", kind, name, fields), Fields: fields}
+}
+
+// ReasonForError returns the reason of err, it is empty if err is not a StatusError.
+func ReasonForError(err error) Reason {
+	if e, ok := err.(*StatusError); ok {
+		return e.Reason
+	}
+	return ""
+}
+
+// IsNotFound returns true if err indicates that the object does not exist.
+func IsNotFound(err error) bool {
+	return ReasonForError(err) == ReasonNotFound
+}
+
+// IsAlreadyExists returns true if err indicates that the object already exists.
+func IsAlreadyExists(err error) bool {
+	return ReasonForError(err) == ReasonAlreadyExists
+}
+
+// IsConflict returns true if err indicates a concurrent modification.
+func IsConflict(err error) bool {
+	return ReasonForError(err) == ReasonConflict
+}
+
+// IsInvalid returns true if err indicates that the object is not valid.
+func IsInvalid(err error) bool {
+	return ReasonForError(err) == ReasonInvalid
+}
+
+// IsForbidden returns true if err indicates that the operation is not allowed.
+func IsForbidden(err error) bool {
+	return ReasonForError(err) == ReasonForbidden
+}
+
+// IsUnavailable returns true if err indicates that the backend is not available.
+func IsUnavailable(err error) bool {
+	return ReasonForError(err) == ReasonUnavailable
+}
+
+// IsUnprocessable returns true if err indicates that the fields of the object are not valid.
+func IsUnprocessable(err error) bool {
+	return ReasonForError(err) == ReasonUnprocessable
+}
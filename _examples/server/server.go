@@ -21,7 +21,9 @@ import (
 
 	"github.com/gorilla/mux"
 	ctrl "github.com/gosoon/code-generator/_examples/server/controller"
-	"github.com/gosoon/code-generator/_examples/server/controller/namespace"
+	"github.com/gosoon/code-generator/_examples/server/controller/secret"
+	"github.com/gosoon/code-generator/_examples/server/controller/v1namespace"
+	"github.com/gosoon/code-generator/_examples/server/controller/v2namespace"
 	"github.com/gosoon/code-generator/_examples/server/service"
 )
 
@@ -46,14 +48,14 @@ type server struct {
 // New is create a server object.
 func New(opt Options) Server {
 	// init service
-	options := &service.Options{
-		KubeClientset: opt.CtrlOptions.KubeClientset,
-	}
+	options := &service.Options{}
 
 	opt.CtrlOptions.Service = service.New(options)
 
 	router := mux.NewRouter().StrictSlash(true)
-	namespace.New(opt.CtrlOptions).Register(router)
+	v1namespace.New(opt.CtrlOptions).Register(router)
+	v2namespace.New(opt.CtrlOptions).Register(router)
+	secret.New(opt.CtrlOptions).Register(router)
 
 	return &server{
 		opt:    opt,
@@ -18,30 +18,79 @@ package service
 import (
 	"context"
 
-	"github.com/gosoon/code-generator/_examples/types/v1"
-	apiv1 "k8s.io/api/core/v1"
-	"k8s.io/client-go/kubernetes"
+	v1 "github.com/gosoon/code-generator/_examples/types/v1"
+	v2 "github.com/gosoon/code-generator/_examples/types/v2"
 )
 
 // Options contains the config by service
 type Options struct {
-	KubeClientset kubernetes.Interface
 }
 
-// service implements the Service interface.
-type service struct {
-	opt *Options
+// ListOptions contains the pagination options of the list methods.
+type ListOptions struct {
+	// Limit is the maximum number of objects to return, zero means no limit.
+	Limit int64
+	// Continue is the token returned by the previous list call to get the next page.
+	Continue string
 }
 
-// New is create a service object.
-func New(opt *Options) Interface {
-	return &service{opt: opt}
+// EventType defines the possible types of watch events.
+type EventType string
+
+const (
+	// Added is sent when an object is created.
+	Added EventType = "ADDED"
+	// Modified is sent when an object is updated.
+	Modified EventType = "MODIFIED"
+	// Deleted is sent when an object is deleted.
+	Deleted EventType = "DELETED"
+)
+
+// WatchEvent is a change of an object sent by the watch methods.
+type WatchEvent struct {
+	Type   EventType   `json:"type"`
+	Object interface{} `json:"object"`
+}
+
+// V1NamespaceList is the result of ListV1Namespace.
+type V1NamespaceList struct {
+	Items []v1.Namespace `json:"items"`
+	// Continue is set if there are more objects, pass it in ListOptions to get them.
+	Continue string `json:"continue,omitempty"`
+}
+
+// V2NamespaceList is the result of ListV2Namespace.
+type V2NamespaceList struct {
+	Items []v2.Namespace `json:"items"`
+	// Continue is set if there are more objects, pass it in ListOptions to get them.
+	Continue string `json:"continue,omitempty"`
+}
+
+// SecretList is the result of ListSecret.
+type SecretList struct {
+	Items []v2.Secret `json:"items"`
+	// Continue is set if there are more objects, pass it in ListOptions to get them.
+	Continue string `json:"continue,omitempty"`
 }
 
 // Interface is definition service all method.
 type Interface interface {
-	CreateNamespace(ctx context.Context, namespaceObj *types.Namespace) error
-	GetNamespace(ctx context.Context, name string) (*apiv1.Namespace, error)
-	UpdateNamespace(ctx context.Context, namespaceObj *types.Namespace) error
-	DeleteNamespace(ctx context.Context, name string) error
+	CreateV1Namespace(ctx context.Context, v1NamespaceObj *v1.Namespace) (*v1.Namespace, error)
+	GetV1Namespace(ctx context.Context, name string) (*v1.Namespace, error)
+	ListV1Namespace(ctx context.Context, opts ListOptions) (*V1NamespaceList, error)
+	WatchV1Namespace(ctx context.Context) (<-chan WatchEvent, error)
+	UpdateV1Namespace(ctx context.Context, v1NamespaceObj *v1.Namespace) error
+	DeleteV1Namespace(ctx context.Context, name string) error
+	CreateV2Namespace(ctx context.Context, v2NamespaceObj *v2.Namespace) (*v2.Namespace, error)
+	GetV2Namespace(ctx context.Context, name string) (*v2.Namespace, error)
+	ListV2Namespace(ctx context.Context, opts ListOptions) (*V2NamespaceList, error)
+	WatchV2Namespace(ctx context.Context) (<-chan WatchEvent, error)
+	UpdateV2Namespace(ctx context.Context, v2NamespaceObj *v2.Namespace) error
+	DeleteV2Namespace(ctx context.Context, name string) error
+	CreateSecret(ctx context.Context, namespace string, secretObj *v2.Secret) (*v2.Secret, error)
+	GetSecret(ctx context.Context, namespace, name string) (*v2.Secret, error)
+	ListSecret(ctx context.Context, namespace string, opts ListOptions) (*SecretList, error)
+	WatchSecret(ctx context.Context, namespace string) (<-chan WatchEvent, error)
+	UpdateSecret(ctx context.Context, namespace string, secretObj *v2.Secret) error
+	DeleteSecret(ctx context.Context, namespace, name string) error
 }
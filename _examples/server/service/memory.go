@@ -0,0 +1,211 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gosoon/code-generator/_examples/server/errors"
+	v1 "github.com/gosoon/code-generator/_examples/types/v1"
+	v2 "github.com/gosoon/code-generator/_examples/types/v2"
+)
+
+// service implements the Service interface, the objects of each type are
+// kept in a store in memory.
+type service struct {
+	opt          *Options
+	v1namespaces *store
+	v2namespaces *store
+	secrets      *store
+}
+
+// New is create a service object with empty stores.
+func New(opt *Options) Interface {
+	return &service{
+		opt:          opt,
+		v1namespaces: newStore("Namespace", func() interface{} { return &v1.Namespace{} }),
+		v2namespaces: newStore("Namespace", func() interface{} { return &v2.Namespace{} }),
+		secrets:      newStore("Secret", func() interface{} { return &v2.Secret{} }),
+	}
+}
+
+// store is a thread-safe in-memory store of the objects of a kind keyed by the
+// namespace and the name. The objects are stored encoded as JSON, so the
+// callers never share an object with the store.
+type store struct {
+	kind      string
+	newObject func() interface{}
+
+	mu      sync.RWMutex
+	objects map[string][]byte
+	// the events are sent while the store is locked, so they are in the
+	// order of the writes
+	*watchers
+}
+
+// newStore returns an empty store of the objects created by newObject.
+func newStore(kind string, newObject func() interface{}) *store {
+	return &store{
+		kind:      kind,
+		newObject: newObject,
+		objects:   map[string][]byte{},
+		watchers:  newWatchers(),
+	}
+}
+
+// storeKey returns the key of an object, cluster-scoped objects have an empty namespace.
+func storeKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// decode returns a new object decoded from data.
+func (s *store) decode(data []byte) (interface{}, error) {
+	obj := s.newObject()
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// create stores obj under name and returns the stored object.
+func (s *store) create(namespace, name string, obj interface{}) (interface{}, error) {
+	if len(name) == 0 {
+		return nil, errors.NewInvalid(s.kind, name, fmt.Errorf("name is required"))
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := storeKey(namespace, name)
+	if _, ok := s.objects[key]; ok {
+		return nil, errors.NewAlreadyExists(s.kind, name)
+	}
+	s.objects[key] = data
+	s.notify(namespace, Added, created)
+	return created, nil
+}
+
+// get returns the object stored under name.
+func (s *store) get(namespace, name string) (interface{}, error) {
+	s.mu.RLock()
+	data, ok := s.objects[storeKey(namespace, name)]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.NewNotFound(s.kind, name)
+	}
+	return s.decode(data)
+}
+
+// list returns the objects of the namespace ordered by name, or the objects of
+// all namespaces if namespace is empty. The returned continue token is set if
+// there are more objects than opts.Limit.
+func (s *store) list(namespace string, opts ListOptions) ([]interface{}, string, error) {
+	start := ""
+	if len(opts.Continue) != 0 {
+		token, err := base64.RawURLEncoding.DecodeString(opts.Continue)
+		if err != nil {
+			return nil, "", &errors.StatusError{Reason: errors.ReasonInvalid, Message: fmt.Sprintf("invalid continue token %q", opts.Continue)}
+		}
+		start = string(token)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var keys []string
+	for key := range s.objects {
+		if len(namespace) != 0 && !strings.HasPrefix(key, storeKey(namespace, "")) {
+			continue
+		}
+		if key < start {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var next string
+	if opts.Limit > 0 && int64(len(keys)) > opts.Limit {
+		next = base64.RawURLEncoding.EncodeToString([]byte(keys[opts.Limit]))
+		keys = keys[:opts.Limit]
+	}
+	objects := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		obj, err := s.decode(s.objects[key])
+		if err != nil {
+			return nil, "", err
+		}
+		objects = append(objects, obj)
+	}
+	return objects, next, nil
+}
+
+// update replaces the object stored under name with the object returned by
+// updateFunc, which is called with a copy of the stored object while the store
+// is locked.
+func (s *store) update(namespace, name string, updateFunc func(current interface{}) interface{}) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := storeKey(namespace, name)
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, errors.NewNotFound(s.kind, name)
+	}
+	current, err := s.decode(data)
+	if err != nil {
+		return nil, err
+	}
+	data, err = json.Marshal(updateFunc(current))
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.decode(data)
+	if err != nil {
+		return nil, err
+	}
+	s.objects[key] = data
+	s.notify(namespace, Modified, updated)
+	return updated, nil
+}
+
+// delete removes the object stored under name.
+func (s *store) delete(namespace, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := storeKey(namespace, name)
+	data, ok := s.objects[key]
+	if !ok {
+		return errors.NewNotFound(s.kind, name)
+	}
+	deleted, err := s.decode(data)
+	if err != nil {
+		return err
+	}
+	delete(s.objects, key)
+	s.notify(namespace, Deleted, deleted)
+	return nil
+}
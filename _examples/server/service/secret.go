@@ -0,0 +1,79 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"context"
+
+	v2 "github.com/gosoon/code-generator/_examples/types/v2"
+)
+
+// CreateSecret xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) CreateSecret(ctx context.Context, namespace string, secretObj *v2.Secret) (*v2.Secret, error) {
+	created, err := s.secrets.create(namespace, secretObj.Name, secretObj)
+	if err != nil {
+		return nil, err
+	}
+	return created.(*v2.Secret), nil
+}
+
+// GetSecret xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) GetSecret(ctx context.Context, namespace, name string) (*v2.Secret, error) {
+	obj, err := s.secrets.get(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*v2.Secret), nil
+}
+
+// ListSecret xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) ListSecret(ctx context.Context, namespace string, opts ListOptions) (*SecretList, error) {
+	objects, next, err := s.secrets.list(namespace, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &SecretList{Items: make([]v2.Secret, 0, len(objects)), Continue: next}
+	for _, obj := range objects {
+		list.Items = append(list.Items, *obj.(*v2.Secret))
+	}
+	return list, nil
+}
+
+// WatchSecret xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+// The events channel must be closed when ctx is done.
+func (s *service) WatchSecret(ctx context.Context, namespace string) (<-chan WatchEvent, error) {
+	return s.secrets.watch(ctx, namespace), nil
+}
+
+// UpdateSecret xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) UpdateSecret(ctx context.Context, namespace string, secretObj *v2.Secret) error {
+	_, err := s.secrets.update(namespace, secretObj.Name, func(current interface{}) interface{} {
+		return secretObj
+	})
+	return err
+}
+
+// DeleteSecret xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) DeleteSecret(ctx context.Context, namespace, name string) error {
+	return s.secrets.delete(namespace, name)
+}
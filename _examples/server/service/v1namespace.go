@@ -0,0 +1,79 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"context"
+
+	v1 "github.com/gosoon/code-generator/_examples/types/v1"
+)
+
+// CreateV1Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) CreateV1Namespace(ctx context.Context, v1NamespaceObj *v1.Namespace) (*v1.Namespace, error) {
+	created, err := s.v1namespaces.create("", v1NamespaceObj.Name, v1NamespaceObj)
+	if err != nil {
+		return nil, err
+	}
+	return created.(*v1.Namespace), nil
+}
+
+// GetV1Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) GetV1Namespace(ctx context.Context, name string) (*v1.Namespace, error) {
+	obj, err := s.v1namespaces.get("", name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*v1.Namespace), nil
+}
+
+// ListV1Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) ListV1Namespace(ctx context.Context, opts ListOptions) (*V1NamespaceList, error) {
+	objects, next, err := s.v1namespaces.list("", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &V1NamespaceList{Items: make([]v1.Namespace, 0, len(objects)), Continue: next}
+	for _, obj := range objects {
+		list.Items = append(list.Items, *obj.(*v1.Namespace))
+	}
+	return list, nil
+}
+
+// WatchV1Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+// The events channel must be closed when ctx is done.
+func (s *service) WatchV1Namespace(ctx context.Context) (<-chan WatchEvent, error) {
+	return s.v1namespaces.watch(ctx, ""), nil
+}
+
+// UpdateV1Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) UpdateV1Namespace(ctx context.Context, v1NamespaceObj *v1.Namespace) error {
+	_, err := s.v1namespaces.update("", v1NamespaceObj.Name, func(current interface{}) interface{} {
+		return v1NamespaceObj
+	})
+	return err
+}
+
+// DeleteV1Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) DeleteV1Namespace(ctx context.Context, name string) error {
+	return s.v1namespaces.delete("", name)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"context"
+
+	v2 "github.com/gosoon/code-generator/_examples/types/v2"
+)
+
+// CreateV2Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) CreateV2Namespace(ctx context.Context, v2NamespaceObj *v2.Namespace) (*v2.Namespace, error) {
+	created, err := s.v2namespaces.create("", v2NamespaceObj.Name, v2NamespaceObj)
+	if err != nil {
+		return nil, err
+	}
+	return created.(*v2.Namespace), nil
+}
+
+// GetV2Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) GetV2Namespace(ctx context.Context, name string) (*v2.Namespace, error) {
+	obj, err := s.v2namespaces.get("", name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*v2.Namespace), nil
+}
+
+// ListV2Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) ListV2Namespace(ctx context.Context, opts ListOptions) (*V2NamespaceList, error) {
+	objects, next, err := s.v2namespaces.list("", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &V2NamespaceList{Items: make([]v2.Namespace, 0, len(objects)), Continue: next}
+	for _, obj := range objects {
+		list.Items = append(list.Items, *obj.(*v2.Namespace))
+	}
+	return list, nil
+}
+
+// WatchV2Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+// The events channel must be closed when ctx is done.
+func (s *service) WatchV2Namespace(ctx context.Context) (<-chan WatchEvent, error) {
+	return s.v2namespaces.watch(ctx, ""), nil
+}
+
+// UpdateV2Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) UpdateV2Namespace(ctx context.Context, v2NamespaceObj *v2.Namespace) error {
+	_, err := s.v2namespaces.update("", v2NamespaceObj.Name, func(current interface{}) interface{} {
+		return v2NamespaceObj
+	})
+	return err
+}
+
+// DeleteV2Namespace xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) DeleteV2Namespace(ctx context.Context, name string) error {
+	return s.v2namespaces.delete("", name)
+}
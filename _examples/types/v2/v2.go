@@ -1,6 +1,7 @@
 package types
 
 // +genclient
+// +genclient:nonNamespaced
 
 // Namespace xxx
 type Namespace struct {
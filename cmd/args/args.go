@@ -27,12 +27,24 @@ import (
 )
 
 // CustomArgs is used by the gengo framework to pass args specific to this generator.
-type CustomArgs struct{}
+type CustomArgs struct {
+	// ForceScaffold overwrites the existing scaffold files, e.g. the service
+	// of the types and the middleware.
+	ForceScaffold bool
+	// Router is the router of the generated server, "mux" for gorilla/mux or
+	// "servemux" for the http.ServeMux of the standard library.
+	Router string
+	// Backend is the storage of the generated services, "memory" for an
+	// in-memory store, "sql" for the tables of a database/sql database,
+	// "kubernetes" for the core kinds of a kubernetes cluster or "crd" for the
+	// custom resources of a kubernetes cluster.
+	Backend string
+}
 
 // NewDefaults returns default arguments for the generator.
 func NewDefaults() (*args.GeneratorArgs, *CustomArgs) {
 	genericArgs := args.Default().WithoutDefaultFlagParsing()
-	customArgs := &CustomArgs{}
+	customArgs := &CustomArgs{Router: "mux", Backend: "memory"}
 	genericArgs.CustomArgs = customArgs
 
 	if pkg := codegenutil.CurrentPackage(); len(pkg) != 0 {
@@ -43,15 +55,29 @@ func NewDefaults() (*args.GeneratorArgs, *CustomArgs) {
 }
 
 // AddFlags add the generator flags to the flag set.
-func (ca *CustomArgs) AddFlags(fs *pflag.FlagSet) {}
+func (ca *CustomArgs) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&ca.ForceScaffold, "force-scaffold", ca.ForceScaffold, "If true, overwrite the existing scaffold files which are only generated if they do not exist.")
+	fs.StringVar(&ca.Router, "router", ca.Router, "The router of the generated server, \"mux\" for github.com/gorilla/mux or \"servemux\" for the http.ServeMux of the standard library, which requires go 1.22.")
+	fs.StringVar(&ca.Backend, "backend", ca.Backend, "The storage of the generated services, \"memory\" for a thread-safe in-memory store, \"sql\" for the tables of a database/sql database, \"kubernetes\" for the core kinds of a kubernetes cluster or \"crd\" for the custom resources of a kubernetes cluster.")
+}
 
 // Validate checks the given arguments.
 func Validate(genericArgs *args.GeneratorArgs) error {
-	_ = genericArgs.CustomArgs.(*CustomArgs)
+	customArgs := genericArgs.CustomArgs.(*CustomArgs)
 
 	if len(genericArgs.OutputPackagePath) == 0 {
 		return fmt.Errorf("output package cannot be empty")
 	}
+	switch customArgs.Router {
+	case "mux", "servemux":
+	default:
+		return fmt.Errorf("unknown router %q, must be \"mux\" or \"servemux\"", customArgs.Router)
+	}
+	switch customArgs.Backend {
+	case "memory", "sql", "kubernetes", "crd":
+	default:
+		return fmt.Errorf("unknown backend %q, must be \"memory\", \"sql\", \"kubernetes\" or \"crd\"", customArgs.Backend)
+	}
 
 	return nil
 }
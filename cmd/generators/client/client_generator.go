@@ -0,0 +1,63 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"github.com/gosoon/code-generator/pkg/args"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// PackageForClient xxx
+func PackageForClient(packagePath string, arguments *args.GeneratorArgs, types []*types.Type, boilerplate []byte) generator.Package {
+	return &generator.DefaultPackage{
+		PackageName: "client",
+		PackagePath: packagePath,
+		HeaderText:  boilerplate,
+		PackageDocumentation: []byte(
+			`// This package has the automatically generated client of the api.
+`),
+		// GeneratorFunc returns a list of generators. Each generator generates a
+		// single file.
+		GeneratorFunc: func(c *generator.Context) (generators []generator.Generator) {
+			generators = []generator.Generator{
+				generator.DefaultGen{OptionalName: "doc"},
+
+				&genClient{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: "client",
+					},
+					outputPackage:   arguments.OutputPackagePath,
+					typesToGenerate: types,
+					imports:         generator.NewImportTracker(),
+				},
+			}
+			// generate the client of echo type
+			for _, t := range types {
+				generators = append(generators, &genTypesClient{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: c.Namers["lowercaseSingular"].Name(t),
+					},
+					outputPackage:  arguments.OutputPackagePath,
+					typeToGenerate: t,
+					imports:        generator.NewImportTracker(),
+				})
+			}
+			return generators
+		},
+	}
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"io"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// genTypesClient generates the client of a type.
+type genTypesClient struct {
+	generator.DefaultGen
+	outputPackage   string
+	imports         namer.ImportTracker
+	clientGenerated bool
+	typeToGenerate  *types.Type
+}
+
+var _ generator.Generator = &genTypesClient{}
+
+func (g *genTypesClient) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+// We only want to call GenerateType() once.
+func (g *genTypesClient) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.clientGenerated
+	g.clientGenerated = true
+	return ret
+}
+
+func (g *genTypesClient) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	return
+}
+
+func (g *genTypesClient) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	klog.Infof("processing type %v", g.typeToGenerate)
+	tags, err := tags.ParseClientGenTags(append(g.typeToGenerate.SecondClosestCommentLines, g.typeToGenerate.CommentLines...))
+	if err != nil {
+		return err
+	}
+
+	m := map[string]interface{}{
+		"type":           g.typeToGenerate,
+		"namespaced":     !tags.NonNamespaced,
+		"path":           pathExpr(c, g.typeToGenerate, util.ResourcePath(g.typeToGenerate, tags)),
+		"collectionPath": pathExpr(c, g.typeToGenerate, util.CollectionPath(g.typeToGenerate, tags)),
+	}
+
+	if tags.HasVerb("list") {
+		sw.Do(typeListStruct, m)
+	}
+	sw.Do(typeInterfaceTmpl, m)
+	if tags.HasVerb("create") {
+		sw.Do(createMethodTmpl, m)
+	}
+	if tags.HasVerb("get") {
+		sw.Do(getMethodTmpl, m)
+	}
+	if tags.HasVerb("list") {
+		sw.Do(listMethodTmpl, m)
+	}
+	if tags.HasVerb("update") {
+		sw.Do(updateMethodTmpl, m)
+	}
+	if tags.HasVerb("delete") {
+		sw.Do(deleteMethodTmpl, m)
+	}
+	sw.Do("}\n", m)
+
+	sw.Do(typeClientTmpl, m)
+	if tags.HasVerb("create") {
+		sw.Do(createTmpl, m)
+	}
+	if tags.HasVerb("get") {
+		sw.Do(getTmpl, m)
+	}
+	if tags.HasVerb("list") {
+		sw.Do(listTmpl, m)
+	}
+	if tags.HasVerb("update") {
+		sw.Do(updateTmpl, m)
+	}
+	if tags.HasVerb("delete") {
+		sw.Do(deleteTmpl, m)
+	}
+	return sw.Error()
+}
+
+// pathExpr returns the go expression of the route path with the api prefix
+// of t, the namespace is escaped from the namespace of the client.
+func pathExpr(c *generator.Context, t *types.Type, path string) string {
+	return util.PathExpr(util.APIPrefix(c.Universe, t)+path, "url.PathEscape(c.namespace)")
+}
+
+var typeListStruct = `
+// $.type|public$List is a page of the $.type|allLowercasePlural$ returned by List.
+type $.type|public$List struct {
+	Items    []$.type|raw$` + "    `json:\"items\"`" + `
+	// Continue is set if there are more objects, pass it in ListOptions to get them.
+	Continue string` + "    `json:\"continue,omitempty\"`" + `
+}
+`
+
+var typeInterfaceTmpl = `
+// $.type|public$Interface has methods to work with $.type|public$ resources.
+type $.type|public$Interface interface {
+`
+
+var createMethodTmpl = `Create(ctx context.Context, $.type|private$Obj *$.type|raw$) (*$.type|raw$, error)
+`
+
+var getMethodTmpl = `Get(ctx context.Context, name string) (*$.type|raw$, error)
+`
+
+var listMethodTmpl = `List(ctx context.Context, opts ListOptions) (*$.type|public$List, error)
+`
+
+var updateMethodTmpl = `Update(ctx context.Context, $.type|private$Obj *$.type|raw$) error
+`
+
+var deleteMethodTmpl = `Delete(ctx context.Context, name string) error
+`
+
+var typeClientTmpl = `
+// $.type|allLowercasePlural$ implements $.type|public$Interface.
+type $.type|allLowercasePlural$ struct {
+	client    *client
+$- if .namespaced$
+	namespace string
+$- end$
+}
+`
+
+var createTmpl = `
+// Create creates the $.type|private$ and returns the object created by the server.
+func (c *$.type|allLowercasePlural$) Create(ctx context.Context, $.type|private$Obj *$.type|raw$) (*$.type|raw$, error) {
+	result := &$.type|raw${}
+	if err := c.client.do(ctx, "POST", $.path$, nil, $.type|private$Obj, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+`
+
+var getTmpl = `
+// Get returns the $.type|private$ of name.
+func (c *$.type|allLowercasePlural$) Get(ctx context.Context, name string) (*$.type|raw$, error) {
+	result := &$.type|raw${}
+	if err := c.client.do(ctx, "GET", $.path$+"/"+url.PathEscape(name), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+`
+
+var listTmpl = `
+// List returns a page of the $.type|allLowercasePlural$.
+func (c *$.type|allLowercasePlural$) List(ctx context.Context, opts ListOptions) (*$.type|public$List, error) {
+	query := url.Values{}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.FormatInt(opts.Limit, 10))
+	}
+	if len(opts.Continue) != 0 {
+		query.Set("continue", opts.Continue)
+	}
+	result := &$.type|public$List{}
+	if err := c.client.do(ctx, "GET", $.collectionPath$, query, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+`
+
+var updateTmpl = `
+// Update updates the $.type|private$ of the name of the object.
+func (c *$.type|allLowercasePlural$) Update(ctx context.Context, $.type|private$Obj *$.type|raw$) error {
+	return c.client.do(ctx, "PUT", $.path$+"/"+url.PathEscape($.type|private$Obj.Name), nil, $.type|private$Obj, nil)
+}
+`
+
+var deleteTmpl = `
+// Delete deletes the $.type|private$ of name.
+func (c *$.type|allLowercasePlural$) Delete(ctx context.Context, name string) error {
+	return c.client.do(ctx, "DELETE", $.path$+"/"+url.PathEscape(name), nil, nil, nil)
+}
+`
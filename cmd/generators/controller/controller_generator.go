@@ -18,8 +18,8 @@ package controller
 
 import (
 	"path/filepath"
-	"strings"
 
+	"github.com/gosoon/code-generator/cmd/generators/util"
 	"github.com/gosoon/code-generator/pkg/args"
 
 	//"k8s.io/gengo/args"
@@ -27,7 +27,7 @@ import (
 	"k8s.io/gengo/types"
 )
 
-func PackageForControllerMeta(packagePath string, arguments *args.GeneratorArgs, boilerplate []byte) generator.Package {
+func PackageForControllerMeta(packagePath string, arguments *args.GeneratorArgs, router util.Router, backend util.Backend, boilerplate []byte) generator.Package {
 	return &generator.DefaultPackage{
 		PackageName: "controller",
 		PackagePath: packagePath, // output path, "pkg/server/controller/{type}"
@@ -45,6 +45,8 @@ func PackageForControllerMeta(packagePath string, arguments *args.GeneratorArgs,
 					outputPackage: arguments.OutputPackagePath, //github.com/gosoon/code-generator
 					//typeToGenerate: t,                           // github.com/gosoon/test/pkg/apis/ecs/v1.KubernetesCluster
 					imports: generator.NewImportTracker(),
+					router:  router,
+					backend: backend,
 				},
 				&genControllerUtils{
 					DefaultGen: generator.DefaultGen{
@@ -54,14 +56,22 @@ func PackageForControllerMeta(packagePath string, arguments *args.GeneratorArgs,
 					//typeToGenerate: t,                           // github.com/gosoon/test/pkg/apis/ecs/v1.KubernetesCluster
 					imports: generator.NewImportTracker(),
 				},
+				&genControllerPatch{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: "patch",
+					},
+					outputPackage: arguments.OutputPackagePath,
+					imports:       generator.NewImportTracker(),
+				},
 			}
 			return generators
 		},
 	}
 }
 
-func PackageForTypesController(packagePath string, arguments *args.GeneratorArgs, t *types.Type, boilerplate []byte) generator.Package {
-	packageName := strings.ToLower(t.Name.Name)
+// PackageForTypesController generates the controller of the type t in the
+// package packageName, the lowercase name of the type.
+func PackageForTypesController(packagePath string, arguments *args.GeneratorArgs, t *types.Type, packageName string, router util.Router, boilerplate []byte) generator.Package {
 	return &generator.DefaultPackage{
 		PackageName: packageName,
 		PackagePath: filepath.Join(packagePath, packageName), // output path, "pkg/server/controller/{type}"
@@ -77,7 +87,23 @@ func PackageForTypesController(packagePath string, arguments *args.GeneratorArgs
 					DefaultGen: generator.DefaultGen{
 						OptionalName: packageName,
 					},
-					inputPackages:  arguments.InputDirs,
+					outputPackage:  arguments.OutputPackagePath,
+					typeToGenerate: t,
+					imports:        generator.NewImportTracker(),
+					router:         router,
+				},
+				&genValidation{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: "validation",
+					},
+					outputPackage:  arguments.OutputPackagePath,
+					typeToGenerate: t,
+					imports:        generator.NewImportTracker(),
+				},
+				&genDefaults{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: "defaults",
+					},
 					outputPackage:  arguments.OutputPackagePath,
 					typeToGenerate: t,
 					imports:        generator.NewImportTracker(),
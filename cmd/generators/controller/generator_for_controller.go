@@ -20,6 +20,9 @@ import (
 	"io"
 	"path/filepath"
 
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/namer"
 	"k8s.io/gengo/types"
@@ -30,11 +33,11 @@ import (
 type genTypesController struct {
 	generator.DefaultGen
 	clientsetPackage   string
-	inputPackages      []string
 	outputPackage      string
 	imports            namer.ImportTracker
 	clientsetGenerated bool
 	typeToGenerate     *types.Type
+	router             util.Router
 }
 
 var _ generator.Generator = &genTypesController{}
@@ -53,11 +56,10 @@ func (g *genTypesController) Filter(c *generator.Context, t *types.Type) bool {
 func (g *genTypesController) Imports(c *generator.Context) (imports []string) {
 	imports = append(imports, g.imports.ImportLines()...)
 	imports = append(imports, filepath.Join(g.outputPackage, "server/controller"))
-	// add input types
-	for _, pkg := range g.inputPackages {
-		imports = append(imports, pkg)
+	imports = append(imports, filepath.Join(g.outputPackage, "server/middleware"))
+	if len(g.router.Import) != 0 {
+		imports = append(imports, g.router.Import)
 	}
-	imports = append(imports, "github.com/gorilla/mux")
 	return
 }
 
@@ -65,17 +67,67 @@ func (g *genTypesController) GenerateType(c *generator.Context, t *types.Type, w
 	sw := generator.NewSnippetWriter(w, c, "$", "$")
 
 	klog.Infof("processing type %v", t)
+	tags, err := tags.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+	if err != nil {
+		return err
+	}
+
 	m := map[string]interface{}{
-		"type": t,
+		"type":           t,
+		"namespaced":     !tags.NonNamespaced,
+		"path":           util.ResourcePath(t, tags),
+		"collectionPath": util.CollectionPath(t, tags),
+		"watch":          tags.HasVerb("watch"),
+		"prefix":         util.APIPrefix(c.Universe, t),
+		"location":       util.PathExpr(util.APIPrefix(c.Universe, t)+util.ResourcePath(t, tags), "namespace"),
+		"router":         g.router,
+		"nameValue":      g.router.PathValue("name"),
+		"namespaceValue": g.router.PathValue("namespace"),
 	}
 
 	sw.Do(typeObjectStruct, m)
 	sw.Do(newObject, m)
-	sw.Do(packRegister, m)
-	sw.Do(createObjectHandler, m)
-	sw.Do(getObjectHandler, m)
-	sw.Do(updateObjectHandler, m)
-	sw.Do(deleteObjectHandler, m)
+
+	sw.Do(packRegister[g.router.Name], m)
+	for _, r := range util.Routes(c, t, tags) {
+		m["route"] = r
+		sw.Do(routeTemplates[g.router.Name], m)
+	}
+	sw.Do("}\n", m)
+
+	hasStatus := util.HasStatus(t, tags)
+
+	if tags.HasVerb("create") {
+		sw.Do(createObjectHandler, m)
+	}
+	if tags.HasVerb("get") {
+		sw.Do(getObjectHandler, m)
+	}
+	if tags.HasVerb("list") {
+		sw.Do(listObjectHandler, m)
+	}
+	if tags.HasVerb("watch") {
+		sw.Do(watchObjectHandler, m)
+	}
+	if tags.HasVerb("update") {
+		sw.Do(updateObjectHandler, m)
+	}
+	if tags.HasVerb("delete") {
+		sw.Do(deleteObjectHandler, m)
+	}
+	if util.HasPatch(tags) {
+		sw.Do(patchObjectHandler, m)
+	}
+	if hasStatus && tags.HasVerb("get") {
+		sw.Do(getStatusHandler, m)
+	}
+	if hasStatus && tags.HasVerb("updateStatus") {
+		sw.Do(updateStatusHandler, m)
+	}
+	for _, e := range util.Extensions(c, t, tags) {
+		m["ext"] = e
+		sw.Do(extensionHandler, m)
+	}
 
 	return sw.Error()
 }
@@ -94,74 +146,143 @@ func New(opt *controller.Options) controller.Controller {
 }
 `
 
-var packRegister = `
+// packRegister are the heads of the Register method by router.
+var packRegister = map[string]string{
+	"mux": `
 // Register is register the routes to router
 func (c *$.type|private$) Register(router *mux.Router) {
-    router = router.PathPrefix("/api/v1").Subrouter()
-
-    // create
-    router.Methods("POST").Path("/$.type|lowercaseSingular$").HandlerFunc(
-        middleware.Authenticate(http.HandlerFunc((c.create$.type|public$))))
-    
-	// get 
-    router.Methods("GET").Path("/$.type|lowercaseSingular$/{name}").HandlerFunc(
-        middleware.Authenticate(http.HandlerFunc((c.get$.type|public$))))
-	
-	// update 
-    router.Methods("PUT").Path("/$.type|lowercaseSingular$").HandlerFunc(
-        middleware.Authenticate(http.HandlerFunc((c.update$.type|public$))))
-	
-	// delete
-    router.Methods("DELETE").Path("/$.type|lowercaseSingular$").HandlerFunc(
-        middleware.Authenticate(http.HandlerFunc((c.delete$.type|public$))))
+    router = router.PathPrefix("$.prefix$").Subrouter()
+`,
+	"servemux": `
+// Register is register the routes to router
+func (c *$.type|private$) Register(router *http.ServeMux) {`,
+}
+
+// routeTemplates register a route by router, the patterns of http.ServeMux
+// contain the method and the full path.
+var routeTemplates = map[string]string{
+	"mux": `
+	// $.route.Comment$
+    router.Methods("$.route.Method$").Path("$.route.Path$").HandlerFunc(
+        middleware.Authenticate(http.HandlerFunc((c.$.route.Handler$))))
+`,
+	"servemux": `
+	// $.route.Comment$
+	router.Handle("$.route.Method$ $.prefix$$.route.Path$",
+		middleware.Authenticate(http.HandlerFunc(c.$.route.Handler$)))
+`,
 }
-`
 
 var createObjectHandler = `
 // create$.type|public$
 func (c *$.type|private$) create$.type|public$(w http.ResponseWriter, r *http.Request) {
-    $.type|private$Obj := &types.$.type|public${}
+$- if .namespaced$
+    namespace := $.namespaceValue$
+$- end$
+    $.type|private$Obj := &$.type|raw${}
     err := json.NewDecoder(r.Body).Decode($.type|private$Obj)
     if err != nil {
         controller.BadRequest(w, r, err)
         return
     }
+    SetDefaults_$.type|public$($.type|private$Obj)
+    if fieldErrors := Validate$.type|public$($.type|private$Obj); len(fieldErrors) != 0 {
+        controller.UnprocessableEntity(w, r, fieldErrors)
+        return
+    }
 
-    err = c.opt.Service.Create$.type|public$(r.Context(), $.type|private$Obj)
+    created, err := c.opt.Service.Create$.type|public$(r.Context(), $if .namespaced$namespace, $end$$.type|private$Obj)
     if err != nil {
-        controller.BadRequest(w, r, err)
+        controller.ServiceError(w, r, err)
         return
     }
-    controller.OK(w, r, "success")
+    location := $.location$ + "/" + created.Name
+    controller.Created(w, r, location, created)
 }
 `
 
 var getObjectHandler = `
 // get$.type|public$
 func (c *$.type|private$) get$.type|public$(w http.ResponseWriter, r *http.Request) {
-	name := mux.Vars(r)["name"]
-	$.type|private$Obj,err := c.opt.Service.Get$.type|public$(r.Context(), name)
+$- if .namespaced$
+	namespace := $.namespaceValue$
+$- end$
+	name := $.nameValue$
+	$.type|private$Obj,err := c.opt.Service.Get$.type|public$(r.Context(), $if .namespaced$namespace, $end$name)
 	if err != nil {
-		controller.BadRequest(w, r, err)
+		controller.ServiceError(w, r, err)
 		return
 	}
 	controller.Response(w, r, http.StatusOK, $.type|private$Obj)
 }
 `
 
+var listObjectHandler = `
+// list$.type|public$
+func (c *$.type|private$) list$.type|public$(w http.ResponseWriter, r *http.Request) {
+$- if .watch$
+	if r.URL.Query().Get("watch") == "true" {
+		c.watch$.type|public$(w, r)
+		return
+	}
+
+$- end$
+	opts, err := controller.ListOptions(r)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	$.type|private$List, err := c.opt.Service.List$.type|public$(r.Context(), $if .namespaced$$.namespaceValue$, $end$opts)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Response(w, r, http.StatusOK, $.type|private$List)
+}
+`
+
+var watchObjectHandler = `
+// watch$.type|public$
+func (c *$.type|private$) watch$.type|public$(w http.ResponseWriter, r *http.Request) {
+	// the service stops sending events when the client disconnects
+	events, err := c.opt.Service.Watch$.type|public$(r.Context()$if .namespaced$, $.namespaceValue$$end$)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Watch(w, r, events)
+}
+`
+
 var updateObjectHandler = `
 // update$.type|public$
 func (c *$.type|private$) update$.type|public$(w http.ResponseWriter, r *http.Request) {
-    $.type|private$Obj := &types.$.type|public${}
+$- if .namespaced$
+	namespace := $.namespaceValue$
+$- end$
+	name := $.nameValue$
+    $.type|private$Obj := &$.type|raw${}
 	err := json.NewDecoder(r.Body).Decode($.type|private$Obj)
 	if err != nil {
 		controller.BadRequest(w, r, err)
 		return
 	}
+	if len($.type|private$Obj.Name) == 0 {
+		$.type|private$Obj.Name = name
+	}
+	if $.type|private$Obj.Name != name {
+		controller.BadRequest(w, r, fmt.Errorf("name %q in body does not match name %q in path", $.type|private$Obj.Name, name))
+		return
+	}
+	if fieldErrors := Validate$.type|public$($.type|private$Obj); len(fieldErrors) != 0 {
+		controller.UnprocessableEntity(w, r, fieldErrors)
+		return
+	}
 
-	err = c.opt.Service.Update$.type|public$(r.Context(), $.type|private$Obj)
+	err = c.opt.Service.Update$.type|public$(r.Context(), $if .namespaced$namespace, $end$$.type|private$Obj)
 	if err != nil {
-		controller.BadRequest(w, r, err)
+		controller.ServiceError(w, r, err)
 		return
 	}
 	controller.OK(w, r, "success")
@@ -171,26 +292,154 @@ func (c *$.type|private$) update$.type|public$(w http.ResponseWriter, r *http.Re
 var deleteObjectHandler = `
 // delete$.type|public$
 func (c *$.type|private$) delete$.type|public$(w http.ResponseWriter, r *http.Request) {
-    $.type|private$Obj := &types.$.type|public${}
-	err := json.NewDecoder(r.Body).Decode($.type|private$Obj)
+$- if .namespaced$
+	namespace := $.namespaceValue$
+$- end$
+	name := $.nameValue$
+	err := c.opt.Service.Delete$.type|public$(r.Context(), $if .namespaced$namespace, $end$name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.OK(w, r, "success")
+}
+`
+
+var patchObjectHandler = `
+// patch$.type|public$
+func (c *$.type|private$) patch$.type|public$(w http.ResponseWriter, r *http.Request) {
+$- if .namespaced$
+	namespace := $.namespaceValue$
+$- end$
+	name := $.nameValue$
+	patch, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		controller.BadRequest(w, r, err)
 		return
 	}
 
 	// get object
-	$.type|private$,err := c.opt.Service.Get$.type|public$(r.Context(), $.type|private$Obj.Name)
+	current, err := c.opt.Service.Get$.type|public$(r.Context(), $if .namespaced$namespace, $end$name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	original, err := json.Marshal(current)
+	if err != nil {
+		controller.InternalError(w, r, err)
+		return
+	}
+
+	patched, err := controller.ApplyPatch(r.Header.Get("Content-Type"), original, patch)
+	if err == controller.ErrUnsupportedPatchType {
+		controller.UnsupportedMediaType(w, r, err)
+		return
+	}
 	if err != nil {
 		controller.BadRequest(w, r, err)
 		return
 	}
 
-	// delete object
-	err = c.opt.Service.Delete$.type|public$(r.Context(), $.type|private$.Name)
+	$.type|private$Obj := &$.type|raw${}
+	err = json.Unmarshal(patched, $.type|private$Obj)
 	if err != nil {
 		controller.BadRequest(w, r, err)
 		return
 	}
+	// the object is addressed by the path, a patch can not rename it
+	$.type|private$Obj.Name = name
+	if fieldErrors := Validate$.type|public$($.type|private$Obj); len(fieldErrors) != 0 {
+		controller.UnprocessableEntity(w, r, fieldErrors)
+		return
+	}
+
+	// update object
+	err = c.opt.Service.Update$.type|public$(r.Context(), $if .namespaced$namespace, $end$$.type|private$Obj)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
 	controller.OK(w, r, "success")
 }
 `
+
+var getStatusHandler = `
+// get$.type|public$Status
+func (c *$.type|private$) get$.type|public$Status(w http.ResponseWriter, r *http.Request) {
+$- if .namespaced$
+	namespace := $.namespaceValue$
+$- end$
+	name := $.nameValue$
+	$.type|private$Obj, err := c.opt.Service.Get$.type|public$Status(r.Context(), $if .namespaced$namespace, $end$name)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Response(w, r, http.StatusOK, $.type|private$Obj)
+}
+`
+
+var updateStatusHandler = `
+// update$.type|public$Status
+func (c *$.type|private$) update$.type|public$Status(w http.ResponseWriter, r *http.Request) {
+$- if .namespaced$
+	namespace := $.namespaceValue$
+$- end$
+	name := $.nameValue$
+	$.type|private$Obj := &$.type|raw${}
+	err := json.NewDecoder(r.Body).Decode($.type|private$Obj)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+	if len($.type|private$Obj.Name) == 0 {
+		$.type|private$Obj.Name = name
+	}
+	if $.type|private$Obj.Name != name {
+		controller.BadRequest(w, r, fmt.Errorf("name %q in body does not match name %q in path", $.type|private$Obj.Name, name))
+		return
+	}
+
+	err = c.opt.Service.Update$.type|public$Status(r.Context(), $if .namespaced$namespace, $end$$.type|private$Obj)
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.OK(w, r, "success")
+}
+`
+
+var extensionHandler = `
+// $.ext.Handler$
+func (c *$.type|private$) $.ext.Handler$(w http.ResponseWriter, r *http.Request) {
+$- if .namespaced$
+	namespace := $.namespaceValue$
+$- end$
+	name := $.nameValue$
+$- if or (.ext.HasVerb "create") (.ext.HasVerb "update")$
+	input := &$.ext.Input${}
+	err := json.NewDecoder(r.Body).Decode(input)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	result, err := c.opt.Service.$.ext.Method$(r.Context(), $if .namespaced$namespace, $end$name, input)
+$- else if .ext.HasVerb "patch"$
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		controller.BadRequest(w, r, err)
+		return
+	}
+
+	result, err := c.opt.Service.$.ext.Method$(r.Context(), $if .namespaced$namespace, $end$name, data)
+$- else$
+	result, err := c.opt.Service.$.ext.Method$(r.Context(), $if .namespaced$namespace, $end$name)
+$- end$
+	if err != nil {
+		controller.ServiceError(w, r, err)
+		return
+	}
+	controller.Response(w, r, http.StatusOK, result)
+}
+`
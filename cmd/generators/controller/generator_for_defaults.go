@@ -0,0 +1,181 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// genDefaults generates the defaulting of the fields of a type from their
+// +default tags.
+type genDefaults struct {
+	generator.DefaultGen
+	outputPackage  string
+	imports        namer.ImportTracker
+	typeToGenerate *types.Type
+}
+
+var _ generator.Generator = &genDefaults{}
+
+func (g *genDefaults) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+func (g *genDefaults) Filter(c *generator.Context, t *types.Type) bool {
+	return t == g.typeToGenerate
+}
+
+func (g *genDefaults) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	return
+}
+
+func (g *genDefaults) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	structs, err := util.Defaults(t)
+	if err != nil {
+		return err
+	}
+	names := defaultsNames(c, structs)
+
+	for i, s := range structs {
+		m := map[string]interface{}{
+			"type":        s.Type,
+			"setDefaults": names[s.Type.Name],
+			"root":        i == 0,
+		}
+		sw.Do(setDefaultsBeginTmpl, m)
+		for _, member := range s.Members {
+			m := map[string]interface{}{
+				"member": member,
+			}
+			if member.Struct != nil {
+				m["setStructDefaults"] = names[member.Struct.Name]
+				sw.Do(setStructDefaultsTmpl[member.Elem], m)
+				continue
+			}
+			if member.Kind == "pointer" {
+				elem := member.Type
+				for elem.Kind == types.Alias {
+					elem = elem.Underlying
+				}
+				elem = elem.Elem
+				// the untyped strings and bools are the type of the pointer
+				m["elem"] = elem
+				m["convert"] = elem.Name != types.String.Name && elem.Name != types.Bool.Name
+			}
+			sw.Do(setMemberDefaultTmpl[member.Kind], m)
+		}
+		sw.Do("}\n", m)
+	}
+	return sw.Error()
+}
+
+// defaultsNames returns the names of the defaulting functions of the struct
+// types, SetDefaults_<Type> for the type of the controller.
+func defaultsNames(c *generator.Context, structs []util.StructDefaults) map[types.Name]string {
+	names := map[types.Name]string{}
+	used := map[string]bool{}
+	for i, s := range structs {
+		prefix := "setDefaults"
+		if i == 0 {
+			prefix = "SetDefaults_"
+		}
+		name := prefix + c.Namers["public"].Name(s.Type)
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s%s%d", prefix, c.Namers["public"].Name(s.Type), i)
+		}
+		used[name] = true
+		names[s.Type.Name] = name
+	}
+	return names
+}
+
+var setDefaultsBeginTmpl = `
+$- if .root$
+// $.setDefaults$ sets the fields of obj which are not set to the values of
+// their +default tags, the fields of the nested structs included.
+$- else$
+// $.setDefaults$ sets the fields of obj which are not set to their default values.
+$- end$
+func $.setDefaults$(obj *$.type|raw$) {`
+
+// setMemberDefaultTmpl sets the default value of the members by kind, the
+// zero values are not set.
+var setMemberDefaultTmpl = map[string]string{
+	"string": `
+	if len(obj.$.member.Name$) == 0 {
+		obj.$.member.Name$ = $.member.Value$
+	}
+`,
+	"number": `
+	if obj.$.member.Name$ == 0 {
+		obj.$.member.Name$ = $.member.Value$
+	}
+`,
+	"pointer": `
+	if obj.$.member.Name$ == nil {
+		value := $if .convert$$.elem|raw$($.member.Value$)$else$$.member.Value$$end$
+		obj.$.member.Name$ = &value
+	}
+`,
+	"slice": `
+	if len(obj.$.member.Name$) == 0 {
+		obj.$.member.Name$ = $.member.Type|raw$$.member.Value$
+	}
+`,
+	"map": `
+	if len(obj.$.member.Name$) == 0 {
+		obj.$.member.Name$ = $.member.Type|raw$$.member.Value$
+	}
+`,
+}
+
+// setStructDefaultsTmpl sets the default values of the members of the struct
+// types by how the member holds the struct.
+var setStructDefaultsTmpl = map[string]string{
+	"struct": `
+	$.setStructDefaults$(&obj.$.member.Name$)
+`,
+	"pointer": `
+	if obj.$.member.Name$ != nil {
+		$.setStructDefaults$(obj.$.member.Name$)
+	}
+`,
+	"slice": `
+	for i := range obj.$.member.Name$ {
+		$.setStructDefaults$(&obj.$.member.Name$[i])
+	}
+`,
+	"pointerSlice": `
+	for i := range obj.$.member.Name$ {
+		if obj.$.member.Name$[i] != nil {
+			$.setStructDefaults$(obj.$.member.Name$[i])
+		}
+	}
+`,
+}
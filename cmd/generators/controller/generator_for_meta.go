@@ -20,6 +20,8 @@ import (
 	"io"
 	"path/filepath"
 
+	"github.com/gosoon/code-generator/cmd/generators/util"
+
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/namer"
 	"k8s.io/gengo/types"
@@ -32,6 +34,8 @@ type genControllerMeta struct {
 	outputPackage       string
 	imports             namer.ImportTracker
 	controllerGenerated bool
+	router              util.Router
+	backend             util.Backend
 
 	typeToGenerate *types.Type
 	objectMeta     *types.Type
@@ -54,32 +58,114 @@ func (g *genControllerMeta) Filter(c *generator.Context, t *types.Type) bool {
 
 func (g *genControllerMeta) Imports(c *generator.Context) (imports []string) {
 	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, "net/http")
 	imports = append(imports, filepath.Join(g.outputPackage, "server/service"))
-	imports = append(imports, "github.com/gorilla/mux")
-	imports = append(imports, "k8s.io/client-go/kubernetes")
+	if len(g.router.Import) != 0 {
+		imports = append(imports, g.router.Import)
+	}
+	imports = append(imports, g.backend.OptionImports()...)
+	imports = append(imports, "k8s.io/klog")
 	return
 }
 
 func (g *genControllerMeta) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
 	sw := generator.NewSnippetWriter(w, c, "$", "$")
 
-	m := map[string]interface{}{}
+	m := map[string]interface{}{
+		"router":  g.router,
+		"backend": g.backend,
+	}
 
 	sw.Do(typeOptionsStruct, m)
 	sw.Do(typeControllerInterface, m)
+	sw.Do(listOptionsFunc, m)
+	sw.Do(watchFunc, m)
 	return sw.Error()
 }
 
 var typeOptionsStruct = `
 // Options contains the config by controller
 type Options struct {
-    KubeClientset              kubernetes.Interface
-    Service                    service.Interface
+$- range .backend.Options$
+    $.Name$ $.Type$
+$- end$
+    Service service.Interface
 }
 `
 var typeControllerInterface = `
 // Controller helps register to router. 
 type Controller interface {
-    Register(router *mux.Router)
+    Register(router $.router.Type$)
+}
+`
+
+var listOptionsFunc = `
+// ListOptions parses the "limit" and "continue" query parameters of a list request.
+func ListOptions(r *http.Request) (service.ListOptions, error) {
+	query := r.URL.Query()
+	opts := service.ListOptions{Continue: query.Get("continue")}
+	if limit := query.Get("limit"); len(limit) != 0 {
+		l, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil || l < 0 {
+			return opts, fmt.Errorf("invalid limit %q, must be a non-negative integer", limit)
+		}
+		opts.Limit = l
+	}
+	return opts, nil
+}
+`
+
+var watchFunc = `
+// Watch streams the events to the client until the events channel is closed or
+// the client disconnects. The events are sent as Server-Sent Events if the client
+// accepts "text/event-stream", otherwise as newline-delimited JSON.
+func Watch(w http.ResponseWriter, r *http.Request, events <-chan service.WatchEvent) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		InternalError(w, r, fmt.Errorf("watch is not supported by the response writer"))
+		return
+	}
+	// the stream outlives the write timeout of the server, the response
+	// writers of go 1.20 and later can clear the deadline
+	if d, ok := w.(interface{ SetWriteDeadline(time.Time) error }); ok {
+		if err := d.SetWriteDeadline(time.Time{}); err != nil {
+			klog.Warningf("clear write deadline of watch failed with:%v", err)
+		}
+	}
+
+	sse := strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+	if sse {
+		w.Header().Set("Content-Type", "text/event-stream")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				klog.Errorf("marshal [%v] failed with err [%v]", event, err)
+				return
+			}
+			if sse {
+				_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			} else {
+				_, err = w.Write(append(data, '\n'))
+			}
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
 }
 `
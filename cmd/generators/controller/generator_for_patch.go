@@ -0,0 +1,99 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"io"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// genControllerPatch generates the patch helpers for the controllers.
+type genControllerPatch struct {
+	generator.DefaultGen
+	outputPackage       string
+	imports             namer.ImportTracker
+	controllerGenerated bool
+}
+
+var _ generator.Generator = &genControllerPatch{}
+
+func (g *genControllerPatch) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+// We only want to call GenerateType() once.
+func (g *genControllerPatch) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.controllerGenerated
+	g.controllerGenerated = true
+	return ret
+}
+
+func (g *genControllerPatch) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, "jsonpatch \"github.com/evanphx/json-patch\"")
+	return
+}
+
+func (g *genControllerPatch) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	m := map[string]interface{}{}
+
+	sw.Do(patchTypesConst, m)
+	sw.Do(applyPatchFunc, m)
+	return sw.Error()
+}
+
+var patchTypesConst = `
+const (
+	// MergePatchType is the content type of a JSON Merge Patch (RFC 7386).
+	MergePatchType = "application/merge-patch+json"
+	// JSONPatchType is the content type of a JSON Patch (RFC 6902).
+	JSONPatchType = "application/json-patch+json"
+)
+
+// ErrUnsupportedPatchType is returned by ApplyPatch for unknown content types.
+var ErrUnsupportedPatchType = fmt.Errorf("unsupported patch content type, use %q or %q", MergePatchType, JSONPatchType)
+`
+
+var applyPatchFunc = `
+// ApplyPatch applies the patch to the original JSON document according to the
+// content type of the request.
+func ApplyPatch(contentType string, original, patch []byte) ([]byte, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedPatchType
+	}
+
+	switch mediaType {
+	case MergePatchType:
+		return jsonpatch.MergePatch(original, patch)
+	case JSONPatchType:
+		p, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, err
+		}
+		return p.Apply(original)
+	}
+	return nil, ErrUnsupportedPatchType
+}
+`
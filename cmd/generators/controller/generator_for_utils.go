@@ -18,6 +18,7 @@ package controller
 
 import (
 	"io"
+	"path/filepath"
 
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/namer"
@@ -55,6 +56,7 @@ func (g *genControllerUtils) Filter(c *generator.Context, t *types.Type) bool {
 func (g *genControllerUtils) Imports(c *generator.Context) (imports []string) {
 	imports = append(imports, g.imports.ImportLines()...)
 	imports = append(imports, "k8s.io/klog")
+	imports = append(imports, filepath.Join(g.outputPackage, "server/errors"))
 	return
 }
 
@@ -68,6 +70,7 @@ func (g *genControllerUtils) GenerateType(c *generator.Context, t *types.Type, w
 
 	sw.Do(typeCommRespStruct, m)
 	sw.Do(respDefine, m)
+	sw.Do(serviceErrorTmpl, m)
 	return sw.Error()
 }
 
@@ -84,6 +87,12 @@ func OK(w http.ResponseWriter, r *http.Request, message string) {
 	Response(w, r, http.StatusOK, message)
 }
 
+// Created reply the created object with the location of the object
+func Created(w http.ResponseWriter, r *http.Request, location string, obj interface{}) {
+	w.Header().Set("Location", location)
+	Response(w, r, http.StatusCreated, obj)
+}
+
 // ResourceNotFound will return an error message indicating that the resource is not exist
 func ResourceNotFound(w http.ResponseWriter, r *http.Request, message string) {
 	Response(w, r, http.StatusNotFound, message)
@@ -124,6 +133,16 @@ func NotAcceptable(w http.ResponseWriter, r *http.Request, err error) {
 	Response(w, r, http.StatusNotAcceptable, err.Error())
 }
 
+// UnprocessableEntity will return the field errors of an object which is not valid
+func UnprocessableEntity(w http.ResponseWriter, r *http.Request, fields errors.FieldErrors) {
+	Response(w, r, http.StatusUnprocessableEntity, fields)
+}
+
+// UnsupportedMediaType will return an error message indicating that the content type of the request is not supported
+func UnsupportedMediaType(w http.ResponseWriter, r *http.Request, err error) {
+	Response(w, r, http.StatusUnsupportedMediaType, err.Error())
+}
+
 // Response : http response func (no return http code)
 func Response(w http.ResponseWriter, r *http.Request, httpCode int, message interface{}) {
 	resp := commResp{
@@ -145,3 +164,26 @@ func Response(w http.ResponseWriter, r *http.Request, httpCode int, message inte
 	}
 }
 `
+
+var serviceErrorTmpl = `
+// ServiceError replies the error returned by the service with the http status code of its reason,
+// errors which are not returned by the errors package are internal errors.
+func ServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch errors.ReasonForError(err) {
+	case errors.ReasonNotFound:
+		ResourceNotFound(w, r, err.Error())
+	case errors.ReasonAlreadyExists, errors.ReasonConflict:
+		Conflict(w, r, err)
+	case errors.ReasonInvalid:
+		BadRequest(w, r, err)
+	case errors.ReasonForbidden:
+		Forbidden(w, r, err)
+	case errors.ReasonUnavailable:
+		ServiceUnavailable(w, r, err)
+	case errors.ReasonUnprocessable:
+		UnprocessableEntity(w, r, err.(*errors.StatusError).Fields)
+	default:
+		InternalError(w, r, err)
+	}
+}
+`
@@ -0,0 +1,260 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// genValidation generates the validation of the fields of a type from their
+// +validation tags.
+type genValidation struct {
+	generator.DefaultGen
+	outputPackage  string
+	imports        namer.ImportTracker
+	typeToGenerate *types.Type
+}
+
+var _ generator.Generator = &genValidation{}
+
+func (g *genValidation) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+func (g *genValidation) Filter(c *generator.Context, t *types.Type) bool {
+	return t == g.typeToGenerate
+}
+
+func (g *genValidation) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, filepath.Join(g.outputPackage, "server/errors"))
+	return
+}
+
+func (g *genValidation) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	structs, err := util.Validations(t)
+	if err != nil {
+		return err
+	}
+	names := validationNames(c, structs)
+
+	sw.Do(validateTmpl, map[string]interface{}{
+		"type":     t,
+		"validate": "validate" + names[t.Name],
+	})
+	for _, s := range structs {
+		for _, member := range s.Members {
+			if len(member.Pattern) == 0 {
+				continue
+			}
+			sw.Do(patternVarTmpl, map[string]interface{}{
+				"var":     patternVar(names[s.Type.Name], member),
+				"pattern": strconv.Quote(member.Pattern),
+			})
+		}
+	}
+
+	for _, s := range structs {
+		m := map[string]interface{}{
+			"type":     s.Type,
+			"validate": "validate" + names[s.Type.Name],
+			"members":  len(s.Members) != 0,
+		}
+		sw.Do(validateStructBeginTmpl, m)
+		for _, member := range s.Members {
+			field := member.Path
+			if len(field) == 0 {
+				field = member.Name
+			}
+			m := map[string]interface{}{
+				"member": member,
+				"field":  strconv.Quote(field),
+			}
+			if member.Required {
+				m["required"] = requiredConditions[member.Kind]
+				sw.Do(requiredTmpl, m)
+			}
+			if len(member.Pattern) != 0 || member.MinLength != 0 || len(member.Enum) != 0 {
+				m["value"] = member.Value("obj")
+				m["minLengthMessage"] = strconv.Quote(minLengthMessage(member.MinLength))
+				m["patternVar"] = patternVar(names[s.Type.Name], member)
+				m["patternMessage"] = strconv.Quote("must match the pattern " + strconv.Quote(member.Pattern))
+				m["enum"] = quoteAll(member.Enum)
+				m["supported"] = strconv.Quote(", supported values: " + quoteAll(member.Enum))
+				sw.Do(stringTmpl, m)
+			}
+			if member.Struct != nil {
+				m["validateStruct"] = "validate" + names[member.Struct.Name]
+				m["path"] = "path"
+				if len(member.Path) != 0 {
+					m["path"] = "path + " + strconv.Quote(member.Path+".")
+				}
+				m["itemPath"] = "path + " + strconv.Quote(member.Path+"[") + ` + strconv.Itoa(i) + "]."`
+				sw.Do(structTmpl[member.Elem], m)
+			}
+		}
+		sw.Do(validateStructEndTmpl, m)
+	}
+	return sw.Error()
+}
+
+// validationNames returns the names of the validation functions of the
+// struct types, the public name of the type with a suffix for the types of
+// other packages with the same name.
+func validationNames(c *generator.Context, structs []util.StructValidation) map[types.Name]string {
+	names := map[types.Name]string{}
+	used := map[string]bool{}
+	for _, s := range structs {
+		name := c.Namers["public"].Name(s.Type)
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s%d", c.Namers["public"].Name(s.Type), i)
+		}
+		used[name] = true
+		names[s.Type.Name] = name
+	}
+	return names
+}
+
+// patternVar returns the name of the compiled pattern of the member of the
+// struct type named name.
+func patternVar(name string, member util.MemberValidation) string {
+	return strings.ToLower(name[:1]) + name[1:] + member.Name + "Pattern"
+}
+
+func minLengthMessage(minLength int) string {
+	if minLength == 1 {
+		return "must have at least 1 character"
+	}
+	return fmt.Sprintf("must have at least %d characters", minLength)
+}
+
+// quoteAll returns the comma separated go literals of values.
+func quoteAll(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// requiredConditions are the conditions of the missing required members by kind.
+var requiredConditions = map[string]string{
+	"string":    "len(obj.%s) == 0",
+	"slice":     "len(obj.%s) == 0",
+	"map":       "len(obj.%s) == 0",
+	"number":    "obj.%s == 0",
+	"pointer":   "obj.%s == nil",
+	"interface": "obj.%s == nil",
+}
+
+var validateTmpl = `
+// Validate$.type|public$ returns the errors of the fields of obj which do not
+// match their +validation tags, the fields are identified by their json path.
+func Validate$.type|public$(obj *$.type|raw$) errors.FieldErrors {
+	return $.validate$(obj, "")
+}
+`
+
+var patternVarTmpl = `
+var $.var$ = regexp.MustCompile($.pattern$)
+`
+
+var validateStructBeginTmpl = `
+// $.validate$ validates the fields of obj, path is the json path of obj.
+func $.validate$(obj *$.type|raw$, path string) errors.FieldErrors {
+$- if .members$
+	var fieldErrors errors.FieldErrors
+$- end$`
+
+var validateStructEndTmpl = `
+$- if .members$
+	return fieldErrors
+$- else$
+	return nil
+$- end$
+}
+`
+
+var requiredTmpl = `
+	if $printf .required .member.Name$ {
+		fieldErrors = append(fieldErrors, errors.FieldError{Field: path + $.field$, Message: "required value"})
+	}
+`
+
+// stringTmpl checks the string tags, the empty strings are only checked by
+// +validation:required.
+var stringTmpl = `
+	if $if .member.StringPointer$obj.$.member.Name$ != nil && len(*obj.$.member.Name$) != 0$else$len(obj.$.member.Name$) != 0$end$ {
+		value := $.value$
+$- if .member.MinLength$
+		if utf8.RuneCountInString(value) < $.member.MinLength$ {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: path + $.field$, Message: $.minLengthMessage$})
+		}
+$- end$
+$- if .member.Pattern$
+		if !$.patternVar$.MatchString(value) {
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: path + $.field$, Message: $.patternMessage$})
+		}
+$- end$
+$- if .member.Enum$
+		switch value {
+		case $.enum$:
+		default:
+			fieldErrors = append(fieldErrors, errors.FieldError{Field: path + $.field$, Message: "unsupported value " + strconv.Quote(value) + $.supported$})
+		}
+$- end$
+	}
+`
+
+// structTmpl validates the members of the struct types by how the member
+// holds the struct.
+var structTmpl = map[string]string{
+	"struct": `
+	fieldErrors = append(fieldErrors, $.validateStruct$(&obj.$.member.Name$, $.path$)...)
+`,
+	"pointer": `
+	if obj.$.member.Name$ != nil {
+		fieldErrors = append(fieldErrors, $.validateStruct$(obj.$.member.Name$, $.path$)...)
+	}
+`,
+	"slice": `
+	for i := range obj.$.member.Name$ {
+		fieldErrors = append(fieldErrors, $.validateStruct$(&obj.$.member.Name$[i], $.itemPath$)...)
+	}
+`,
+	"pointerSlice": `
+	for i := range obj.$.member.Name$ {
+		if obj.$.member.Name$[i] != nil {
+			fieldErrors = append(fieldErrors, $.validateStruct$(obj.$.member.Name$[i], $.itemPath$)...)
+		}
+	}
+`,
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crd
+
+import (
+	"io"
+	"sort"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/args"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+	"sigs.k8s.io/yaml"
+)
+
+// FileType is the file type of the CustomResourceDefinition manifests, it
+// must be registered in the context with NewFileType.
+const FileType = "crd"
+
+// NewFileType returns the file type of the CustomResourceDefinition
+// manifests, the json body is converted to yaml without the header.
+func NewFileType() generator.FileType {
+	return &generator.DefaultFileType{
+		Format: yaml.JSONToYAML,
+		Assemble: func(w io.Writer, f *generator.File) {
+			w.Write(f.Body.Bytes())
+		},
+	}
+}
+
+// PackageForCRDs generates a CustomResourceDefinition manifest of each custom
+// resource of the types, the types of the same resource in several group
+// versions are the versions of one definition.
+func PackageForCRDs(packagePath string, arguments *args.GeneratorArgs, typesToGenerate []*types.Type) generator.Package {
+	return &generator.DefaultPackage{
+		PackageName: "crd",
+		PackagePath: packagePath,
+		// GeneratorFunc returns a list of generators. Each generator generates a
+		// single file.
+		GeneratorFunc: func(c *generator.Context) (generators []generator.Generator) {
+			var names []string
+			versions := map[string][]*types.Type{}
+			for _, t := range typesToGenerate {
+				r := util.CustomResourceFor(c, t)
+				name := r.Plural + "." + r.Group
+				if _, ok := versions[name]; !ok {
+					names = append(names, name)
+				}
+				versions[name] = append(versions[name], t)
+			}
+			for _, name := range names {
+				// the version of the first input package is the storage version
+				resourceTypes := versions[name]
+				sort.SliceStable(resourceTypes, func(i, j int) bool {
+					return inputIndex(arguments, resourceTypes[i]) < inputIndex(arguments, resourceTypes[j])
+				})
+				r := util.CustomResourceFor(c, resourceTypes[0])
+				generators = append(generators, &genCRD{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: r.Group + "_" + r.Plural,
+					},
+					typesToGenerate: resourceTypes,
+				})
+			}
+			return generators
+		},
+	}
+}
+
+// inputIndex returns the index of the input dir of the package of t.
+func inputIndex(arguments *args.GeneratorArgs, t *types.Type) int {
+	for i, dir := range arguments.InputDirs {
+		if dir == t.Name.Package {
+			return i
+		}
+	}
+	return len(arguments.InputDirs)
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crd
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/gosoon/code-generator/cmd/generators/openapi"
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// genCRD generates the CustomResourceDefinition of the custom resource which
+// stores the objects of the types in the crd backend.
+type genCRD struct {
+	generator.DefaultGen
+	crdGenerated bool
+	// typesToGenerate are the versions of the custom resource, the first is
+	// the storage version.
+	typesToGenerate []*types.Type
+}
+
+var _ generator.Generator = &genCRD{}
+
+func (g *genCRD) Filename() string { return g.Name() + ".yaml" }
+func (g *genCRD) FileType() string { return FileType }
+
+// We only want to call GenerateType() once.
+func (g *genCRD) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.crdGenerated
+	g.crdGenerated = true
+	return ret
+}
+
+func (g *genCRD) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	klog.Infof("processing type %v", g.typesToGenerate[0])
+	r := util.CustomResourceFor(c, g.typesToGenerate[0])
+	crd := customResourceDefinition{
+		APIVersion: "apiextensions.k8s.io/v1",
+		Kind:       "CustomResourceDefinition",
+		Metadata:   objectMeta{Name: r.Plural + "." + r.Group},
+		Spec: customResourceDefinitionSpec{
+			Group: r.Group,
+			Names: customResourceDefinitionNames{
+				Plural:   r.Plural,
+				Singular: strings.ToLower(r.Kind),
+				Kind:     r.Kind,
+				ListKind: r.Kind + "List",
+			},
+		},
+	}
+	for i, t := range g.typesToGenerate {
+		tags, err := tags.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+		if err != nil {
+			return err
+		}
+		version := customResourceDefinitionVersion{
+			Name:    util.CustomResourceFor(c, t).Version,
+			Served:  true,
+			Storage: i == 0,
+			Schema:  customResourceValidation{OpenAPIV3Schema: resourceSchema(t)},
+		}
+		// the scope of the storage version is the scope of the resource
+		if i == 0 {
+			crd.Spec.Scope = "Namespaced"
+			if tags.NonNamespaced {
+				crd.Spec.Scope = "Cluster"
+			}
+		}
+		if util.HasStatus(t, tags) {
+			version.Subresources = &customResourceSubresources{Status: &struct{}{}}
+		}
+		crd.Spec.Versions = append(crd.Spec.Versions, version)
+	}
+	return json.NewEncoder(w).Encode(crd)
+}
+
+// customResourceDefinition is the apiextensions.k8s.io/v1 CustomResourceDefinition.
+type customResourceDefinition struct {
+	APIVersion string                       `json:"apiVersion"`
+	Kind       string                       `json:"kind"`
+	Metadata   objectMeta                   `json:"metadata"`
+	Spec       customResourceDefinitionSpec `json:"spec"`
+}
+
+type objectMeta struct {
+	Name string `json:"name"`
+}
+
+type customResourceDefinitionSpec struct {
+	Group    string                            `json:"group"`
+	Names    customResourceDefinitionNames     `json:"names"`
+	Scope    string                            `json:"scope"`
+	Versions []customResourceDefinitionVersion `json:"versions"`
+}
+
+type customResourceDefinitionNames struct {
+	Plural   string `json:"plural"`
+	Singular string `json:"singular"`
+	Kind     string `json:"kind"`
+	ListKind string `json:"listKind"`
+}
+
+type customResourceDefinitionVersion struct {
+	Name         string                      `json:"name"`
+	Served       bool                        `json:"served"`
+	Storage      bool                        `json:"storage"`
+	Schema       customResourceValidation    `json:"schema"`
+	Subresources *customResourceSubresources `json:"subresources,omitempty"`
+}
+
+type customResourceValidation struct {
+	OpenAPIV3Schema *openapi.Schema `json:"openAPIV3Schema"`
+}
+
+type customResourceSubresources struct {
+	Status *struct{} `json:"status,omitempty"`
+}
+
+// resourceSchema returns the schema of the custom resource of t, it has the
+// layout of the conversions of the crd backend: the name of the objects is
+// the name of the metadata, the "Status" member is the status and the other
+// members are the spec.
+func resourceSchema(t *types.Type) *openapi.Schema {
+	// structural schemas do not have references
+	s := openapi.NewBuilder("").Schema(t)
+	nameKey, statusKey := util.MemberJSONName(t, "Name"), util.MemberJSONName(t, "Status")
+
+	spec := &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{}}
+	for name, property := range s.Properties {
+		if name != nameKey && name != statusKey {
+			spec.Properties[name] = property
+		}
+	}
+	for _, name := range s.Required {
+		if name != nameKey && name != statusKey {
+			spec.Required = append(spec.Required, name)
+		}
+	}
+	structural(spec)
+
+	resource := &openapi.Schema{
+		Type:        "object",
+		Description: s.Description,
+		Properties: map[string]*openapi.Schema{
+			"apiVersion": {Type: "string"},
+			"kind":       {Type: "string"},
+			"metadata":   {Type: "object"},
+			"spec":       spec,
+		},
+	}
+	if status, ok := s.Properties[statusKey]; ok {
+		structural(status)
+		resource.Properties["status"] = status
+	}
+	return resource
+}
+
+// structural makes the schema s structural: the values of any type and the
+// objects without known properties keep their fields, and the required
+// slices, maps and interfaces accept the null which encoding/json encodes for
+// nil.
+func structural(s *openapi.Schema) {
+	if len(s.Type) == 0 || (s.Type == "object" && s.Properties == nil && s.AdditionalProperties == nil) {
+		s.XPreserveUnknownFields = true
+	}
+	for _, property := range s.Properties {
+		structural(property)
+	}
+	for _, name := range s.Required {
+		property := s.Properties[name]
+		if len(property.Type) == 0 || property.Type == "array" || property.Format == "byte" || property.AdditionalProperties != nil {
+			property.Nullable = true
+		}
+	}
+	if s.Items != nil {
+		structural(s.Items)
+	}
+	if s.AdditionalProperties != nil {
+		structural(s.AdditionalProperties)
+	}
+}
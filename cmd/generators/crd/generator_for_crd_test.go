@@ -0,0 +1,64 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crd
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func TestResourceSchema(t *testing.T) {
+	meta := &types.Type{
+		Name: types.Name{Package: "example.com/v1", Name: "Meta"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Name", Type: types.String, Tags: `json:"name"`},
+		},
+	}
+	status := &types.Type{
+		Name: types.Name{Package: "example.com/v1", Name: "WidgetStatus"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Ready", Type: types.Bool, Tags: `json:"ready"`},
+		},
+	}
+	widget := &types.Type{
+		Name: types.Name{Package: "example.com/v1", Name: "Widget"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Meta", Embedded: true, Type: meta, Tags: `json:",inline"`},
+			{Name: "Replicas", Type: types.Int32, Tags: `json:"replicas"`, CommentLines: []string{"+default=1"}},
+			{Name: "Order", Type: types.Int, Tags: `json:"order"`},
+			{Name: "Status", Type: status, Tags: `json:"status"`},
+		},
+	}
+
+	s := resourceSchema(widget)
+	spec := s.Properties["spec"]
+	if _, ok := spec.Properties["name"]; ok {
+		t.Errorf("expected the name of the embedded metadata to be left out of the spec")
+	}
+	if _, ok := s.Properties["status"]; !ok {
+		t.Errorf("expected the status property")
+	}
+	expected := []string{"order"}
+	if !reflect.DeepEqual(spec.Required, expected) {
+		t.Errorf("expected the required fields %v, got %v", expected, spec.Required)
+	}
+}
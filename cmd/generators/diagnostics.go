@@ -0,0 +1,146 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generators
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/gengo/types"
+)
+
+// Diagnostic is an error found in the tags of an input type.
+type Diagnostic struct {
+	// Position is the declaration of the type, or of the member for the errors
+	// in the tags of a member, the filename is empty if the declaration can
+	// not be found.
+	Position token.Position
+	// Type is the name of the type, the name is empty for the errors of the
+	// package tags.
+	Type types.Name
+	// Err is the error found in the tags.
+	Err error
+}
+
+// Error returns the diagnostic in the "file:line: type X: message" format.
+func (d Diagnostic) Error() string {
+	location := d.Type.Package
+	if d.Position.IsValid() {
+		location = fmt.Sprintf("%s:%d", d.Position.Filename, d.Position.Line)
+	}
+	if len(d.Type.Name) == 0 {
+		// the error is in the tags of the package
+		return fmt.Sprintf("%s: %v", location, d.Err)
+	}
+	return fmt.Sprintf("%s: type %s: %v", location, d.Type.Name, d.Err)
+}
+
+// Diagnostics is a list of errors found in the input types.
+type Diagnostics []Diagnostic
+
+// Error returns all diagnostics, one per line.
+func (d Diagnostics) Error() string {
+	lines := make([]string, 0, len(d))
+	for _, diagnostic := range d {
+		lines = append(lines, diagnostic.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Sort sorts the diagnostics by position.
+func (d Diagnostics) Sort() {
+	sort.Slice(d, func(i, j int) bool {
+		a, b := d[i].Position, d[j].Position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return d[i].Type.Name < d[j].Type.Name
+	})
+}
+
+// typePositions returns the positions of the type declarations in the source
+// files of the package, indexed by the type name, and of the members of the
+// struct types, indexed by "Type.Member".
+func typePositions(p *types.Package) map[string]token.Position {
+	positions := map[string]token.Position{}
+	fset := token.NewFileSet()
+	notTest := func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}
+	pkgs, err := parser.ParseDir(fset, p.SourcePath, notTest, 0)
+	if err != nil {
+		return positions
+	}
+	wd, _ := os.Getwd()
+	position := func(pos token.Pos) token.Position {
+		position := fset.Position(pos)
+		if rel, err := filepath.Rel(wd, position.Filename); err == nil && !strings.HasPrefix(rel, "..") {
+			position.Filename = rel
+		}
+		return position
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					positions[typeSpec.Name.Name] = position(typeSpec.Pos())
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, field := range structType.Fields.List {
+						for _, name := range field.Names {
+							positions[typeSpec.Name.Name+"."+name.Name] = position(name.Pos())
+						}
+						if len(field.Names) == 0 {
+							positions[typeSpec.Name.Name+"."+embeddedName(field.Type)] = position(field.Pos())
+						}
+					}
+				}
+			}
+		}
+	}
+	return positions
+}
+
+// embeddedName returns the name of the member of an embedded type, which is
+// the name of the type without its package.
+func embeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"github.com/gosoon/code-generator/pkg/args"
+	"k8s.io/gengo/generator"
+)
+
+// PackageForErrors xxx
+func PackageForErrors(packagePath string, arguments *args.GeneratorArgs, boilerplate []byte) generator.Package {
+	return &generator.DefaultPackage{
+		PackageName: "errors",
+		PackagePath: packagePath,
+		HeaderText:  boilerplate,
+		PackageDocumentation: []byte(
+			`// This package has the automatically generated errors returned by the service.
+`),
+		// GeneratorFunc returns a list of generators. Each generator generates a
+		// single file.
+		GeneratorFunc: func(c *generator.Context) (generators []generator.Generator) {
+			generators = []generator.Generator{
+				generator.DefaultGen{OptionalName: "doc"},
+
+				&genErrors{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: "errors",
+					},
+					outputPackage: arguments.OutputPackagePath,
+					imports:       generator.NewImportTracker(),
+				},
+			}
+			return generators
+		},
+	}
+}
@@ -17,19 +17,27 @@
 package generators
 
 import (
+	"fmt"
+	"go/token"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	generatorargs "github.com/gosoon/code-generator/cmd/args"
+	"github.com/gosoon/code-generator/cmd/generators/client"
 	"github.com/gosoon/code-generator/cmd/generators/controller"
+	"github.com/gosoon/code-generator/cmd/generators/crd"
+	"github.com/gosoon/code-generator/cmd/generators/errors"
 	"github.com/gosoon/code-generator/cmd/generators/middleware"
+	"github.com/gosoon/code-generator/cmd/generators/openapi"
 	"github.com/gosoon/code-generator/cmd/generators/service"
+	"github.com/gosoon/code-generator/cmd/generators/util"
 	"github.com/gosoon/code-generator/pkg/args"
 	"github.com/gosoon/code-generator/pkg/tags"
 
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/namer"
 	"k8s.io/gengo/types"
-	"k8s.io/klog"
 )
 
 // NameSystems returns the name system used by the generators in this package.
@@ -41,9 +49,34 @@ func NameSystems() namer.NameSystems {
 		"publicPlural":       namer.NewPublicPluralNamer(nil),
 		"allLowercasePlural": namer.NewAllLowercasePluralNamer(nil),
 		"lowercaseSingular":  &lowercaseSingularNamer{},
+		// the names of the kubernetes kinds are not versioned
+		"kind":       namer.NewPublicNamer(0),
+		"kindPlural": namer.NewPublicPluralNamer(nil),
 	}
 }
 
+// versionedNames are the name systems of the generated identifiers, which are
+// prefixed with the group version for types served by multiple versions.
+var versionedNames = []string{"public", "private", "publicPlural", "allLowercasePlural", "lowercaseSingular"}
+
+// versionedNamer prepends the group version to the names of the types which
+// have the same name in multiple group versions, e.g. "V2Namespace".
+type versionedNamer struct {
+	namer    namer.Namer
+	prefixes map[types.Name]string
+}
+
+// Name returns the name of t with the prefix of its group version.
+func (n *versionedNamer) Name(t *types.Type) string {
+	prefix, ok := n.prefixes[t.Name]
+	if !ok {
+		return n.namer.Name(t)
+	}
+	versioned := *t
+	versioned.Name.Name = prefix + t.Name.Name
+	return n.namer.Name(&versioned)
+}
+
 // lowercaseSingularNamer implements Namer
 type lowercaseSingularNamer struct{}
 
@@ -58,7 +91,7 @@ func DefaultNameSystem() string {
 	return "public"
 }
 
-func packageForServer(serverPackagePath string, arguments *args.GeneratorArgs, types []*types.Type, boilerplate []byte) generator.Package {
+func packageForServer(serverPackagePath string, arguments *args.GeneratorArgs, types []*types.Type, router util.Router, backend util.Backend, boilerplate []byte) generator.Package {
 	return &generator.DefaultPackage{
 		PackageName: "server",
 		PackagePath: serverPackagePath,
@@ -78,6 +111,8 @@ func packageForServer(serverPackagePath string, arguments *args.GeneratorArgs, t
 					imports:         generator.NewImportTracker(),
 					outputPackage:   arguments.OutputPackagePath,
 					typesToGenerate: types,
+					router:          router,
+					backend:         backend,
 				},
 			}
 			return generators
@@ -86,51 +121,311 @@ func packageForServer(serverPackagePath string, arguments *args.GeneratorArgs, t
 }
 
 // Packages makes the client package definition.
-func Packages(context *generator.Context, arguments *args.GeneratorArgs) generator.Packages {
+func Packages(context *generator.Context, arguments *args.GeneratorArgs) (generator.Packages, error) {
 	// load license
 	boilerplate, err := arguments.LoadGoBoilerplate()
 	if err != nil {
-		klog.Fatalf("Failed loading boilerplate: %v", err)
+		return nil, fmt.Errorf("Failed loading boilerplate: %v", err)
 	}
 
-	var packageList []generator.Package
+	customArgs := arguments.CustomArgs.(*generatorargs.CustomArgs)
+
+	// the OpenAPI document and the CustomResourceDefinitions are not go files
+	context.FileTypes[openapi.FileType] = openapi.NewFileType()
+	context.FileTypes[crd.FileType] = crd.NewFileType()
+	// the files edited by the user are only written if they do not exist
+	context.FileTypes[util.ScaffoldFileType] = util.NewScaffoldFile(customArgs.ForceScaffold)
+
+	// all types of the input packages are served by one server
+	var typesToGenerate []*types.Type
+	var diagnostics Diagnostics
+	groupVersions := map[string]util.GroupVersion{}
 	for _, inputDir := range arguments.InputDirs {
 		// Package returns the Package for the given path.
 		// package save all types and tags
 		p := context.Universe.Package(inputDir)
+		gv, err := util.PackageGroupVersion(p)
+		if err != nil {
+			diagnostics = append(diagnostics, Diagnostic{Type: types.Name{Package: p.Path}, Err: err})
+		} else if customArgs.Backend == "crd" && len(gv.Group) == 0 {
+			// custom resources are not served by the core group
+			err := fmt.Errorf("the crd backend requires a group, add a +groupVersion=<group>/<version> tag")
+			diagnostics = append(diagnostics, Diagnostic{Type: types.Name{Package: p.Path}, Err: err})
+		}
+		groupVersions[p.Path] = gv
 
 		// filter have GenTags types
-		var typesToGenerate []*types.Type
-		for _, t := range p.Types {
-			tags := tags.MustParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
-			if !tags.GenerateClient {
+		pkgTypes, errs := filterTypes(p)
+		diagnostics = append(diagnostics, errs...)
+		typesToGenerate = append(typesToGenerate, pkgTypes...)
+	}
+	diagnostics = append(diagnostics, nameCollisions(context.Universe, groupVersions, typesToGenerate)...)
+	if len(diagnostics) > 0 {
+		diagnostics.Sort()
+		return nil, diagnostics
+	}
+	if len(typesToGenerate) == 0 {
+		return nil, nil
+	}
+	orderer := namer.Orderer{Namer: namer.NewPrivateNamer(0)}
+	typesToGenerate = orderer.OrderTypes(typesToGenerate)
+
+	// types with the same name are served side by side by their group versions
+	prefixes := versionPrefixes(groupVersions, typesToGenerate)
+	for _, name := range versionedNames {
+		context.Namers[name] = &versionedNamer{namer: context.Namers[name], prefixes: prefixes}
+	}
+	// the extension methods are named after the versioned types
+	if diagnostics := methodCollisions(context, typesToGenerate); len(diagnostics) > 0 {
+		diagnostics.Sort()
+		return nil, diagnostics
+	}
+
+	packagePath := filepath.Join(arguments.OutputPackagePath, "server/controller")
+	serverPackagePath := filepath.Join(arguments.OutputPackagePath, "server")
+	servicePackagePath := filepath.Join(arguments.OutputPackagePath, "server/service")
+	middlewarePackagePath := filepath.Join(arguments.OutputPackagePath, "server/middleware")
+	errorsPackagePath := filepath.Join(arguments.OutputPackagePath, "server/errors")
+	clientPackagePath := filepath.Join(arguments.OutputPackagePath, "client")
+	apiPackagePath := filepath.Join(arguments.OutputPackagePath, "api")
+	crdPackagePath := filepath.Join(arguments.OutputPackagePath, "config/crd")
+
+	router := util.Routers[customArgs.Router]
+	backend := util.Backends[customArgs.Backend]
+
+	var packageList []generator.Package
+	packageList = append(packageList, packageForServer(serverPackagePath, arguments, typesToGenerate, router, backend, boilerplate))
+	packageList = append(packageList, controller.PackageForControllerMeta(packagePath, arguments, router, backend, boilerplate))
+	packageList = append(packageList, service.PackageForService(servicePackagePath, arguments, typesToGenerate, backend, boilerplate))
+	packageList = append(packageList, errors.PackageForErrors(errorsPackagePath, arguments, boilerplate))
+
+	// client
+	packageList = append(packageList, client.PackageForClient(clientPackagePath, arguments, typesToGenerate, boilerplate))
+
+	// OpenAPI document
+	packageList = append(packageList, openapi.PackageForOpenAPI(apiPackagePath, arguments, typesToGenerate))
+
+	// CustomResourceDefinitions of the types stored as custom resources
+	if backend.Name == "crd" {
+		packageList = append(packageList, crd.PackageForCRDs(crdPackagePath, arguments, typesToGenerate))
+	}
+
+	// middleware
+	packageList = append(packageList, middleware.PackageForMiddleware(middlewarePackagePath, arguments, boilerplate))
+	// generate CRUD method for echo type
+	for _, t := range typesToGenerate {
+		name := context.Namers["lowercaseSingular"].Name(t)
+		packageList = append(packageList, controller.PackageForTypesController(packagePath,
+			arguments, t, name, router, boilerplate))
+		packageList = append(packageList, service.PackageForTypes(servicePackagePath, arguments, t, name, backend, boilerplate))
+	}
+	return generator.Packages(packageList), nil
+}
+
+// nameCollisions returns a diagnostic for every type whose lowercase name is
+// used by a type of an earlier input package of the same group version, the
+// generated routes are named after the type. The types of different group
+// versions whose generated names have the same version prefix, e.g. the
+// group versions apps.a.com/v1 and apps.b.com/v1, are reported as well.
+func nameCollisions(u types.Universe, groupVersions map[string]util.GroupVersion, typesToGenerate []*types.Type) Diagnostics {
+	var diagnostics Diagnostics
+	seen := map[string]*types.Type{}
+	prefixed := map[string]*types.Type{}
+	positions := map[string]map[string]token.Position{}
+	report := func(t *types.Type, err error) {
+		if positions[t.Name.Package] == nil {
+			positions[t.Name.Package] = typePositions(u.Package(t.Name.Package))
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Position: positions[t.Name.Package][t.Name.Name],
+			Type:     t.Name,
+			Err:      err,
+		})
+	}
+	for _, t := range typesToGenerate {
+		gv := groupVersions[t.Name.Package]
+		key := gv.Prefix() + "/" + strings.ToLower(t.Name.Name)
+		if first, ok := seen[key]; ok {
+			report(t, fmt.Errorf("name collides with type %s of package %s in group version %s",
+				first.Name.Name, first.Name.Package, gv))
+			continue
+		}
+		seen[key] = t
+
+		prefix := versionPrefix(gv)
+		key = prefix + "/" + strings.ToLower(t.Name.Name)
+		if first, ok := prefixed[key]; ok {
+			report(t, fmt.Errorf("generated names collide with type %s of package %s in group version %s, both are prefixed with %s",
+				first.Name.Name, first.Name.Package, groupVersions[first.Name.Package], prefix))
+			continue
+		}
+		prefixed[key] = t
+	}
+	return diagnostics
+}
+
+// methodCollisions returns a diagnostic for every +genclient:method extension
+// whose service method has the name of another method of the service
+// interface, e.g. the extension "Get" of a type without the get verb.
+func methodCollisions(c *generator.Context, typesToGenerate []*types.Type) Diagnostics {
+	methods := map[string]types.Name{}
+	typeTags := make([]tags.Tags, len(typesToGenerate))
+	for i, t := range typesToGenerate {
+		// the tags are checked by filterTypes
+		typeTags[i], _ = tags.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+		public := c.Namers["public"].Name(t)
+		for _, verb := range []string{"create", "get", "list", "watch", "update", "delete"} {
+			if typeTags[i].HasVerb(verb) {
+				methods[publicName(verb)+public] = t.Name
+			}
+		}
+		if util.HasStatus(t, typeTags[i]) {
+			if typeTags[i].HasVerb("get") {
+				methods["Get"+public+"Status"] = t.Name
+			}
+			if typeTags[i].HasVerb("updateStatus") {
+				methods["Update"+public+"Status"] = t.Name
+			}
+		}
+	}
+
+	var diagnostics Diagnostics
+	positions := map[string]map[string]token.Position{}
+	for i, t := range typesToGenerate {
+		for _, e := range typeTags[i].Extensions {
+			method := util.ExtensionMethod(c, t, e.VerbName)
+			other, ok := methods[method]
+			if !ok {
+				methods[method] = t.Name
 				continue
 			}
-			typesToGenerate = append(typesToGenerate, t)
+			if positions[t.Name.Package] == nil {
+				positions[t.Name.Package] = typePositions(c.Universe.Package(t.Name.Package))
+			}
+			diagnostics = append(diagnostics, Diagnostic{
+				Position: positions[t.Name.Package][t.Name.Name],
+				Type:     t.Name,
+				Err: fmt.Errorf("%s: service method %s collides with a method of type %s of package %s",
+					e.VerbName, method, other.Name, other.Package),
+			})
 		}
-		if len(typesToGenerate) == 0 {
+	}
+	return diagnostics
+}
+
+// versionPrefixes returns the prefixes of the generated identifiers of the
+// types whose lowercase name is served by multiple group versions, e.g. "V2"
+// or "AppsV1".
+func versionPrefixes(groupVersions map[string]util.GroupVersion, typesToGenerate []*types.Type) map[types.Name]string {
+	count := map[string]int{}
+	for _, t := range typesToGenerate {
+		count[strings.ToLower(t.Name.Name)]++
+	}
+	prefixes := map[types.Name]string{}
+	for _, t := range typesToGenerate {
+		if count[strings.ToLower(t.Name.Name)] < 2 {
 			continue
 		}
-		orderer := namer.Orderer{Namer: namer.NewPrivateNamer(0)}
-		typesToGenerate = orderer.OrderTypes(typesToGenerate)
+		prefixes[t.Name] = versionPrefix(groupVersions[t.Name.Package])
+	}
+	return prefixes
+}
+
+// versionPrefix returns the prefix of the generated names of the types of the
+// group version gv, the first label of the group and the version, e.g.
+// "AppsV1" for apps.example.com/v1.
+func versionPrefix(gv util.GroupVersion) string {
+	group := strings.Split(gv.Group, ".")[0]
+	return publicName(group) + publicName(gv.Version)
+}
+
+// publicName returns s with the first letter and the letters after a "-" in
+// upper case.
+func publicName(s string) string {
+	var ret string
+	for _, part := range strings.Split(s, "-") {
+		if len(part) != 0 {
+			ret += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return ret
+}
 
-		packagePath := filepath.Join(arguments.OutputPackagePath, "server/controller")
-		serverPackagePath := filepath.Join(arguments.OutputPackagePath, "server")
-		servicePackagePath := filepath.Join(arguments.OutputPackagePath, "server/service")
-		middlewarePackagePath := filepath.Join(arguments.OutputPackagePath, "server/middleware")
+// filterTypes returns the types of the package which have the +genclient tag,
+// the tag errors of all types are returned as diagnostics.
+func filterTypes(p *types.Package) ([]*types.Type, Diagnostics) {
+	var typesToGenerate []*types.Type
+	var diagnostics Diagnostics
+	var positions map[string]token.Position
+	// reported are the field errors already reported, the errors of a struct
+	// type used by several types are reported once.
+	reported := map[string]bool{}
+	names := make([]string, 0, len(p.Types))
+	for name := range p.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := p.Types[name]
+		var errs []error
+		tags, err := tags.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			errs = validateExtensions(p, tags)
+		}
+		var fieldErrs util.FieldErrors
+		if tags.GenerateClient {
+			if _, err := util.Validations(t); err != nil {
+				fieldErrs = append(fieldErrs, err.(util.FieldErrors)...)
+			}
+			if _, err := util.Defaults(t); err != nil {
+				fieldErrs = append(fieldErrs, err.(util.FieldErrors)...)
+			}
+		}
+		if len(errs) == 0 && len(fieldErrs) == 0 {
+			if tags.GenerateClient {
+				typesToGenerate = append(typesToGenerate, t)
+			}
+			continue
+		}
 
-		packageList = append(packageList, packageForServer(serverPackagePath, arguments, typesToGenerate, boilerplate))
-		packageList = append(packageList, controller.PackageForControllerMeta(packagePath, arguments, boilerplate))
-		packageList = append(packageList, service.PackageForService(servicePackagePath, arguments, typesToGenerate, boilerplate))
+		if positions == nil {
+			positions = typePositions(p)
+		}
+		for _, err := range errs {
+			diagnostics = append(diagnostics, Diagnostic{Position: positions[t.Name.Name], Type: t.Name, Err: err})
+		}
+		for _, err := range fieldErrs {
+			if reported[err.Error()] {
+				continue
+			}
+			reported[err.Error()] = true
+			position := positions[t.Name.Name]
+			if member, ok := positions[err.Type.Name+"."+err.Member]; ok && err.Type.Package == p.Path {
+				position = member
+			}
+			diagnostics = append(diagnostics, Diagnostic{Position: position, Type: t.Name, Err: err})
+		}
+	}
+	return typesToGenerate, diagnostics
+}
 
-		// middleware
-		packageList = append(packageList, middleware.PackageForMiddleware(middlewarePackagePath, arguments, boilerplate))
-		// generate CRUD method for echo type
-		for _, t := range typesToGenerate {
-			packageList = append(packageList, controller.PackageForTypesController(packagePath,
-				arguments, t, boilerplate))
-			packageList = append(packageList, service.PackageForTypes(servicePackagePath, arguments, t, boilerplate))
+// validateExtensions checks that the input and result types of the extensions
+// without a package exist in the package of the type.
+func validateExtensions(p *types.Package, tags tags.Tags) []error {
+	var errs []error
+	for _, e := range tags.Extensions {
+		if len(e.InputTypeOverride) > 0 {
+			if name, pkg := e.Input(); len(pkg) == 0 && p.Types[name] == nil {
+				errs = append(errs, fmt.Errorf("%s: input type %q does not exist in package %s", e.VerbName, name, p.Path))
+			}
+		}
+		if len(e.ResultTypeOverride) > 0 {
+			if name, pkg := e.Result(); len(pkg) == 0 && p.Types[name] == nil {
+				errs = append(errs, fmt.Errorf("%s: result type %q does not exist in package %s", e.VerbName, name, p.Path))
+			}
 		}
 	}
-	return generator.Packages(packageList)
+	return errs
 }
@@ -0,0 +1,305 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generators
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	generatorargs "github.com/gosoon/code-generator/cmd/args"
+)
+
+// generatedServers are the servers generated from the types of the testdata/e2e
+// module, the tests of testdata/e2e/_tests/<name> run in the server <name>.
+var generatedServers = []struct {
+	name      string
+	inputDirs []string
+	backend   string
+	router    string
+}{
+	{name: "memory", inputDirs: []string{"./types/v1"}, backend: "memory", router: "mux"},
+	{name: "sql", inputDirs: []string{"./types/v1"}, backend: "sql", router: "servemux"},
+	{name: "crd", inputDirs: []string{"./types/v1"}, backend: "crd", router: "mux"},
+	{name: "versions", inputDirs: []string{"./types/v1", "./types/v2"}, backend: "memory", router: "mux"},
+}
+
+// TestGeneratedServers generates the servers in a copy of the testdata/e2e
+// module and runs the tests of the module, the scaffold tests included.
+func TestGeneratedServers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the tests of the generated servers in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+
+	src, err := filepath.Abs("testdata/e2e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "e2e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := copyDir(src, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range generatedServers {
+		genericArgs, customArgs := generatorargs.NewDefaults()
+		genericArgs.InputDirs = s.inputDirs
+		genericArgs.OutputPackagePath = "example.com/e2e/out/" + s.name
+		customArgs.Backend, customArgs.Router = s.backend, s.router
+		if err := generatorargs.Validate(genericArgs); err != nil {
+			t.Fatal(err)
+		}
+		if err := genericArgs.Execute(NameSystems(), DefaultNameSystem(), Packages); err != nil {
+			t.Fatalf("generating the %s server failed with:%v", s.name, err)
+		}
+
+		tests := filepath.Join(src, "_tests", s.name)
+		if _, err := os.Stat(tests); err == nil {
+			if err := copyDir(tests, filepath.Join(dir, "out", s.name)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	cmd := exec.Command("go", "test", "./...")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("testing the generated servers failed with:%v\n%s", err, out)
+	}
+}
+
+// TestScaffolds tests that the existing scaffolds, the services of the crd
+// backend and their tests, are kept unless they are forced.
+func TestScaffolds(t *testing.T) {
+	src, err := filepath.Abs("testdata/e2e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "e2e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := copyDir(src, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	generate := func(force bool) {
+		genericArgs, customArgs := generatorargs.NewDefaults()
+		genericArgs.InputDirs = []string{"./types/v1"}
+		genericArgs.OutputPackagePath = "example.com/e2e/out/scaffolds"
+		customArgs.Backend, customArgs.ForceScaffold = "crd", force
+		if err := generatorargs.Validate(genericArgs); err != nil {
+			t.Fatal(err)
+		}
+		if err := genericArgs.Execute(NameSystems(), DefaultNameSystem(), Packages); err != nil {
+			t.Fatalf("generating the server failed with:%v", err)
+		}
+	}
+	scaffolds := []string{"out/scaffolds/server/service/widget.go", "out/scaffolds/server/service/widget_test.go"}
+	const edited = "// edited by the user\n"
+
+	generate(false)
+	for _, scaffold := range scaffolds {
+		if err := ioutil.WriteFile(scaffold, []byte(edited), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	generate(false)
+	for _, scaffold := range scaffolds {
+		if data, err := ioutil.ReadFile(scaffold); err != nil || string(data) != edited {
+			t.Errorf("expected the scaffold %s to be kept, %v", scaffold, err)
+		}
+	}
+	generate(true)
+	for _, scaffold := range scaffolds {
+		if data, err := ioutil.ReadFile(scaffold); err != nil || string(data) == edited {
+			t.Errorf("expected the scaffold %s to be overwritten, %v", scaffold, err)
+		}
+	}
+}
+
+// TestMissingBoilerplate tests that a missing boilerplate file is returned as
+// an error.
+func TestMissingBoilerplate(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir("testdata/e2e"); err != nil {
+		t.Fatal(err)
+	}
+
+	genericArgs, _ := generatorargs.NewDefaults()
+	genericArgs.InputDirs = []string{"./types/v1"}
+	genericArgs.OutputPackagePath = "example.com/e2e/out/boilerplate"
+	genericArgs.GoHeaderFilePath = "missing.go.txt"
+	err = genericArgs.Execute(NameSystems(), DefaultNameSystem(), Packages)
+	if err == nil || !strings.Contains(err.Error(), "Failed loading boilerplate") {
+		t.Fatalf("expected a boilerplate error, got %v", err)
+	}
+}
+
+// TestMethodCollisions tests the diagnostics of the extensions named like
+// another method of the service.
+func TestMethodCollisions(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir("testdata/e2e"); err != nil {
+		t.Fatal(err)
+	}
+
+	genericArgs, _ := generatorargs.NewDefaults()
+	genericArgs.InputDirs = []string{"./types/collisions"}
+	genericArgs.OutputPackagePath = "example.com/e2e/out/collisions"
+	err = genericArgs.Execute(NameSystems(), DefaultNameSystem(), Packages)
+	diagnostics, ok := err.(Diagnostics)
+	if !ok {
+		t.Fatalf("expected diagnostics, got %v", err)
+	}
+	expected := []string{
+		"Get: service method GetGadget collides with a method of type Gadget of package example.com/e2e/types/collisions",
+		"ListTool: service method ListToolGadget collides with a method of type ToolGadget of package example.com/e2e/types/collisions",
+	}
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %v", len(expected), diagnostics)
+	}
+	for i, d := range diagnostics {
+		if d.Err.Error() != expected[i] {
+			t.Errorf("expected the diagnostic %q, got %q", expected[i], d.Err)
+		}
+	}
+}
+
+// TestPrefixCollisions tests the diagnostics of the types of different group
+// versions whose generated names have the same version prefix.
+func TestPrefixCollisions(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir("testdata/e2e"); err != nil {
+		t.Fatal(err)
+	}
+
+	genericArgs, _ := generatorargs.NewDefaults()
+	genericArgs.InputDirs = []string{"./types/prefixes/a", "./types/prefixes/b"}
+	genericArgs.OutputPackagePath = "example.com/e2e/out/prefixes"
+	err = genericArgs.Execute(NameSystems(), DefaultNameSystem(), Packages)
+	diagnostics, ok := err.(Diagnostics)
+	if !ok {
+		t.Fatalf("expected diagnostics, got %v", err)
+	}
+	expected := "types/prefixes/b/types.go:6: type Widget: generated names collide with type Widget of package example.com/e2e/types/prefixes/a in group version apps.a.com/v1, both are prefixed with AppsV1"
+	if len(diagnostics) != 1 || diagnostics[0].Error() != expected {
+		t.Fatalf("expected the diagnostic %q, got %v", expected, diagnostics)
+	}
+}
+
+// TestTagDiagnostics tests that all the tag errors of the types are reported,
+// the errors of the fields at the position of the fields.
+func TestTagDiagnostics(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir("testdata/e2e"); err != nil {
+		t.Fatal(err)
+	}
+
+	genericArgs, _ := generatorargs.NewDefaults()
+	genericArgs.InputDirs = []string{"./types/invalid"}
+	genericArgs.OutputPackagePath = "example.com/e2e/out/invalid"
+	err = genericArgs.Execute(NameSystems(), DefaultNameSystem(), Packages)
+	diagnostics, ok := err.(Diagnostics)
+	if !ok {
+		t.Fatalf("expected diagnostics, got %v", err)
+	}
+	expected := []string{
+		`types/invalid/types.go:8: type Sprocket: Scale: input type "Scale" does not exist in package example.com/e2e/types/invalid`,
+		`types/invalid/types.go:8: type Sprocket: Scale: result type "Scale" does not exist in package example.com/e2e/types/invalid`,
+		`types/invalid/types.go:10: type Sprocket: field Sprocket.Replicas: +validation:minLength is only supported for string fields`,
+		`types/invalid/types.go:12: type Sprocket: field Sprocket.Size: invalid +default=yes: expected an integer: strconv.ParseInt: parsing "yes": invalid syntax`,
+		`types/invalid/types.go:19: type Cog: field SprocketSpec.Count: +validation:pattern is only supported for string fields`,
+	}
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %v", len(expected), diagnostics)
+	}
+	for i, d := range diagnostics {
+		if d.Error() != expected[i] {
+			t.Errorf("expected the diagnostic %q, got %q", expected[i], d.Error())
+		}
+	}
+}
+
+// copyDir copies the files of src to dst, the directories whose name starts
+// with "_" are skipped like the go command does.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if rel != "." && info.Name()[0] == '_' {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filepath.Join(dst, rel), data, 0644)
+	})
+}
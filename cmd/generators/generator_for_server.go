@@ -20,7 +20,8 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
-	"strings"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
 
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/namer"
@@ -36,6 +37,8 @@ type genServer struct {
 	imports             namer.ImportTracker
 	controllerGenerated bool
 	typesToGenerate     []*types.Type
+	router              util.Router
+	backend             util.Backend
 }
 
 var _ generator.Generator = &genServer{}
@@ -57,10 +60,12 @@ func (g *genServer) Imports(c *generator.Context) (imports []string) {
 	imports = append(imports, g.imports.ImportLines()...)
 	imports = append(imports, filepath.Join(g.outputPackage, "server/service"))
 	imports = append(imports, fmt.Sprintf("ctrl \"%v\"", filepath.Join(g.outputPackage, "server/controller")))
-	imports = append(imports, "github.com/gorilla/mux")
+	if len(g.router.Import) != 0 {
+		imports = append(imports, g.router.Import)
+	}
 
 	for _, t := range g.typesToGenerate {
-		imports = append(imports, filepath.Join(g.outputPackage, "server/controller", strings.ToLower(t.Name.Name)))
+		imports = append(imports, filepath.Join(g.outputPackage, "server/controller", c.Namers["lowercaseSingular"].Name(t)))
 	}
 	return
 }
@@ -70,7 +75,9 @@ func (g *genServer) GenerateType(c *generator.Context, t *types.Type, w io.Write
 
 	klog.Infof("processing type %v", t)
 	m := map[string]interface{}{
-		"types": g.typesToGenerate,
+		"types":   g.typesToGenerate,
+		"router":  g.router,
+		"backend": g.backend,
 	}
 
 	sw.Do(typeServerInterface, m)
@@ -102,7 +109,7 @@ var typeServerStruct = `
 // server implements the Server interface.
 type server struct {
 	opt    Options
-	router *mux.Router
+	router $.router.Type$
 }
 `
 
@@ -111,13 +118,15 @@ var serverNewFunc = `
 func New(opt Options) Server {
 	// init service
 	options := &service.Options{
-		KubeClientset:  opt.CtrlOptions.KubeClientset,
+$- range .backend.Options$
+		$.Name$: opt.CtrlOptions.$.Name$,
+$- end$
 	}
 
 	opt.CtrlOptions.Service = service.New(options)
 
-	router := mux.NewRouter().StrictSlash(true)
-	$range .types$ $.|private$.New(opt.CtrlOptions).Register(router)
+	router := $.router.New$
+	$range .types$ $.|lowercaseSingular$.New(opt.CtrlOptions).Register(router)
 	$end$
 
 	return &server{
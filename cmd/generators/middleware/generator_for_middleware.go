@@ -20,6 +20,8 @@ import (
 	"io"
 	"path/filepath"
 
+	"github.com/gosoon/code-generator/cmd/generators/util"
+
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/namer"
 	"k8s.io/gengo/types"
@@ -40,6 +42,9 @@ type genMiddleware struct {
 
 var _ generator.Generator = &genMiddleware{}
 
+// FileType makes the middleware a scaffold, the user implements it.
+func (g *genMiddleware) FileType() string { return util.ScaffoldFileType }
+
 func (g *genMiddleware) Namers(c *generator.Context) namer.NameSystems {
 	return namer.NameSystems{
 		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
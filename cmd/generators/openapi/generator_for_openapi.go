@@ -0,0 +1,413 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+const (
+	// schemaPrefix is the prefix of the references to the schemas.
+	schemaPrefix = "#/components/schemas/"
+	// responsePrefix is the prefix of the references to the error responses.
+	responsePrefix = "#/components/responses/"
+)
+
+// genOpenAPI generates the OpenAPI document of the routes of all types.
+type genOpenAPI struct {
+	generator.DefaultGen
+	openAPIGenerated bool
+	typesToGenerate  []*types.Type
+}
+
+var _ generator.Generator = &genOpenAPI{}
+
+func (g *genOpenAPI) Filename() string { return g.Name() + ".json" }
+func (g *genOpenAPI) FileType() string { return FileType }
+
+// We only want to call GenerateType() once.
+func (g *genOpenAPI) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.openAPIGenerated
+	g.openAPIGenerated = true
+	return ret
+}
+
+func (g *genOpenAPI) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	klog.Infof("processing type %v", t)
+	b := NewBuilder(schemaPrefix)
+	// the generated types served by multiple versions have versioned names
+	b.Namer = c.Namers["public"]
+	paths := map[string]map[string]*operation{}
+	add := func(method, path string, op *operation) {
+		if paths[path] == nil {
+			paths[path] = map[string]*operation{}
+		}
+		paths[path][strings.ToLower(method)] = op
+	}
+
+	for _, t := range g.typesToGenerate {
+		tags, err := tags.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+		if err != nil {
+			return err
+		}
+		o := operations{
+			builder:    b,
+			t:          t,
+			name:       c.Namers["public"].Name(t),
+			namespaced: !tags.NonNamespaced,
+		}
+		prefix := util.APIPrefix(c.Universe, t)
+		// the operations of the routes of the controller
+		for _, r := range util.Routes(c, t, tags) {
+			var op *operation
+			switch r.Verb {
+			case "create":
+				op = o.create()
+			case "get":
+				op = o.get()
+			case "list":
+				op = o.list(tags.HasVerb("watch"))
+			case "watch":
+				op = o.watch()
+			case "update":
+				op = o.update()
+			case "delete":
+				op = o.delete()
+			case "patch":
+				op = o.patch()
+			case "getStatus":
+				op = o.getStatus()
+			case "updateStatus":
+				op = o.updateStatus()
+			case "extension":
+				op = o.extension(*r.Extension)
+			}
+			add(r.Method, prefix+r.Path, op)
+		}
+	}
+
+	b.Definitions["CommResp"] = commRespSchema()
+	b.Definitions["WatchEvent"] = watchEventSchema()
+	b.Definitions["FieldError"] = fieldErrorSchema()
+	doc := document{
+		OpenAPI: "3.0.0",
+		Info:    info{Title: "restful api", Version: "v1"},
+		Paths:   paths,
+		Components: components{
+			Schemas:   b.Definitions,
+			Responses: errorResponses(),
+		},
+	}
+	return json.NewEncoder(w).Encode(doc)
+}
+
+// document is the root of the OpenAPI document.
+type document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       info                             `json:"info"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components components                       `json:"components"`
+}
+
+type info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type components struct {
+	Schemas   map[string]*Schema   `json:"schemas"`
+	Responses map[string]*response `json:"responses"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Tags        []string             `json:"tags"`
+	Parameters  []*parameter         `json:"parameters,omitempty"`
+	RequestBody *requestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*mediaType `json:"content"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type response struct {
+	Ref         string                `json:"$ref,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Headers     map[string]*header    `json:"headers,omitempty"`
+	Content     map[string]*mediaType `json:"content,omitempty"`
+}
+
+type header struct {
+	Schema *Schema `json:"schema"`
+}
+
+// errorCodes are the status codes of the errors replied by the controller,
+// the service errors are replied with 403, 404, 409, 422 and 503.
+var errorCodes = []int{
+	http.StatusBadRequest,
+	http.StatusUnauthorized,
+	http.StatusForbidden,
+	http.StatusNotFound,
+	http.StatusConflict,
+	http.StatusUnsupportedMediaType,
+	http.StatusUnprocessableEntity,
+	http.StatusInternalServerError,
+	http.StatusServiceUnavailable,
+}
+
+// errorResponses returns the responses of the error codes, the message of
+// the commResp envelope is the error message, or the field errors for 422.
+func errorResponses() map[string]*response {
+	responses := map[string]*response{}
+	for _, code := range errorCodes {
+		responses[strconv.Itoa(code)] = &response{
+			Description: http.StatusText(code),
+			Content:     jsonContent(&Schema{Ref: schemaPrefix + "CommResp"}),
+		}
+	}
+	responses[strconv.Itoa(http.StatusUnprocessableEntity)].Content = jsonContent(envelope(&Schema{
+		Type:  "array",
+		Items: &Schema{Ref: schemaPrefix + "FieldError"},
+	}))
+	return responses
+}
+
+// commRespSchema returns the schema of the commResp envelope of the errors.
+func commRespSchema() *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":    {Type: "string", Description: "the text of the http status code"},
+			"message": {Type: "string"},
+		},
+		Required: []string{"code", "message"},
+	}
+}
+
+// watchEventSchema returns the schema of the events of the watch routes.
+func watchEventSchema() *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"type":   {Type: "string", Description: "ADDED, MODIFIED or DELETED"},
+			"object": {Type: "object"},
+		},
+		Required: []string{"type", "object"},
+	}
+}
+
+// fieldErrorSchema returns the schema of the field errors of the objects
+// which do not match the validation tags of their fields.
+func fieldErrorSchema() *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"field":   {Type: "string", Description: "the json path of the field"},
+			"message": {Type: "string"},
+		},
+		Required: []string{"field", "message"},
+	}
+}
+
+// envelope returns the schema of the commResp envelope of message.
+func envelope(message *Schema) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":    {Type: "string", Description: "the text of the http status code"},
+			"message": message,
+		},
+		Required: []string{"code", "message"},
+	}
+}
+
+func jsonContent(s *Schema) map[string]*mediaType {
+	return map[string]*mediaType{"application/json": {Schema: s}}
+}
+
+// operations builds the operations of a type.
+type operations struct {
+	builder *Builder
+	t       *types.Type
+	// name is the name of the type in the generated code, e.g. "V2Namespace".
+	name       string
+	namespaced bool
+}
+
+// id returns the operation id of verb, e.g. "createPod".
+func (o operations) id(verb string) string {
+	return verb + o.name
+}
+
+// newOperation returns an operation with the path parameters of the route,
+// the success response and the error responses of codes.
+func (o operations) newOperation(id string, name bool, code int, message *Schema, codes ...int) *operation {
+	op := &operation{
+		OperationID: id,
+		Tags:        []string{o.name},
+		Responses: map[string]*response{
+			strconv.Itoa(code): {
+				Description: http.StatusText(code),
+				Content:     jsonContent(envelope(message)),
+			},
+		},
+	}
+	if o.namespaced {
+		op.Parameters = append(op.Parameters, &parameter{Name: "namespace", In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	if name {
+		op.Parameters = append(op.Parameters, &parameter{Name: "name", In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	// every route is authenticated and replies the service errors
+	codes = append(codes, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	for _, code := range codes {
+		op.Responses[strconv.Itoa(code)] = &response{Ref: responsePrefix + strconv.Itoa(code)}
+	}
+	return op
+}
+
+// success is the message of the routes which do not reply an object.
+func success() *Schema {
+	return &Schema{Type: "string"}
+}
+
+func (o operations) object() *Schema {
+	return o.builder.Schema(o.t)
+}
+
+func (o operations) body(s *Schema) *requestBody {
+	return &requestBody{Required: true, Content: jsonContent(s)}
+}
+
+func (o operations) create() *operation {
+	op := o.newOperation(o.id("create"), false, http.StatusCreated, o.object(), http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity)
+	op.RequestBody = o.body(o.object())
+	op.Responses[strconv.Itoa(http.StatusCreated)].Headers = map[string]*header{
+		"Location": {Schema: &Schema{Type: "string"}},
+	}
+	return op
+}
+
+func (o operations) get() *operation {
+	return o.newOperation(o.id("get"), true, http.StatusOK, o.object(), http.StatusNotFound)
+}
+
+func (o operations) list(watch bool) *operation {
+	list := o.name + "List"
+	o.builder.Definitions[list] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"items":    {Type: "array", Items: o.object()},
+			"continue": {Type: "string", Description: "the token to get the next page"},
+		},
+		Required: []string{"items"},
+	}
+	op := o.newOperation(o.id("list"), false, http.StatusOK, &Schema{Ref: schemaPrefix + list}, http.StatusBadRequest)
+	op.Parameters = append(op.Parameters,
+		&parameter{Name: "limit", In: "query", Schema: &Schema{Type: "integer", Format: "int64"}},
+		&parameter{Name: "continue", In: "query", Schema: &Schema{Type: "string"}},
+	)
+	if watch {
+		o.addWatch(op)
+	}
+	return op
+}
+
+func (o operations) watch() *operation {
+	op := o.newOperation(o.id("watch"), false, http.StatusOK, &Schema{Ref: schemaPrefix + "WatchEvent"})
+	o.addWatch(op)
+	return op
+}
+
+// addWatch adds the watch parameter and the streaming responses to op.
+func (o operations) addWatch(op *operation) {
+	op.Parameters = append(op.Parameters, &parameter{Name: "watch", In: "query", Schema: &Schema{Type: "boolean"}})
+	event := &mediaType{Schema: &Schema{Ref: schemaPrefix + "WatchEvent"}}
+	content := op.Responses[strconv.Itoa(http.StatusOK)].Content
+	content["text/event-stream"] = event
+	content["application/x-ndjson"] = event
+}
+
+func (o operations) update() *operation {
+	op := o.newOperation(o.id("update"), true, http.StatusOK, success(),
+		http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity)
+	op.RequestBody = o.body(o.object())
+	return op
+}
+
+func (o operations) delete() *operation {
+	return o.newOperation(o.id("delete"), true, http.StatusOK, success(), http.StatusNotFound)
+}
+
+func (o operations) patch() *operation {
+	op := o.newOperation(o.id("patch"), true, http.StatusOK, success(),
+		http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity)
+	op.RequestBody = &requestBody{
+		Required: true,
+		Content: map[string]*mediaType{
+			"application/merge-patch+json": {Schema: &Schema{Type: "object"}},
+			"application/json-patch+json":  {Schema: &Schema{Type: "array", Items: &Schema{Type: "object"}}},
+		},
+	}
+	return op
+}
+
+func (o operations) getStatus() *operation {
+	return o.newOperation(o.id("get")+"Status", true, http.StatusOK, o.object(), http.StatusNotFound)
+}
+
+func (o operations) updateStatus() *operation {
+	op := o.newOperation(o.id("update")+"Status", true, http.StatusOK, success(),
+		http.StatusBadRequest, http.StatusNotFound, http.StatusConflict)
+	op.RequestBody = o.body(o.object())
+	return op
+}
+
+func (o operations) extension(e util.Extension) *operation {
+	op := o.newOperation(o.id(e.Handler), true, http.StatusOK, o.builder.Schema(e.ResultType), http.StatusBadRequest, http.StatusNotFound)
+	switch e.VerbType {
+	case "create", "update":
+		op.RequestBody = o.body(o.builder.Schema(e.InputType))
+	case "patch":
+		op.RequestBody = o.body(&Schema{Type: "object"})
+	}
+	return op
+}
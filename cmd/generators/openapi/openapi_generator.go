@@ -0,0 +1,71 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/gosoon/code-generator/pkg/args"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// FileType is the file type of the OpenAPI documents, it must be registered
+// in the context with NewFileType.
+const FileType = "openapi"
+
+// NewFileType returns the file type of the OpenAPI documents, the body is
+// indented json without the header.
+func NewFileType() generator.FileType {
+	return &generator.DefaultFileType{
+		Format: func(src []byte) ([]byte, error) {
+			var out bytes.Buffer
+			if err := json.Indent(&out, src, "", "  "); err != nil {
+				return nil, err
+			}
+			out.WriteString("\n")
+			return out.Bytes(), nil
+		},
+		Assemble: func(w io.Writer, f *generator.File) {
+			w.Write(f.Body.Bytes())
+		},
+	}
+}
+
+// PackageForOpenAPI xxx
+func PackageForOpenAPI(packagePath string, arguments *args.GeneratorArgs, types []*types.Type) generator.Package {
+	return &generator.DefaultPackage{
+		PackageName: "api",
+		PackagePath: packagePath,
+		// GeneratorFunc returns a list of generators. Each generator generates a
+		// single file.
+		GeneratorFunc: func(c *generator.Context) (generators []generator.Generator) {
+			generators = []generator.Generator{
+				&genOpenAPI{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: "openapi",
+					},
+					typesToGenerate: types,
+				},
+			}
+			return generators
+		},
+	}
+}
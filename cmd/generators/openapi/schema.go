@@ -0,0 +1,238 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openapi
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// Schema is the OpenAPI schema of a go type.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	// XPreserveUnknownFields keeps the unknown fields of the objects of the
+	// CustomResourceDefinitions.
+	XPreserveUnknownFields bool `json:"x-kubernetes-preserve-unknown-fields,omitempty"`
+}
+
+// builtinSchemas maps the builtin types and the well known types which are
+// encoded as json strings to their schemas.
+var builtinSchemas = map[types.Name]Schema{
+	{Name: "string"}:  {Type: "string"},
+	{Name: "bool"}:    {Type: "boolean"},
+	{Name: "int"}:     {Type: "integer", Format: "int64"},
+	{Name: "int8"}:    {Type: "integer", Format: "int32"},
+	{Name: "int16"}:   {Type: "integer", Format: "int32"},
+	{Name: "int32"}:   {Type: "integer", Format: "int32"},
+	{Name: "rune"}:    {Type: "integer", Format: "int32"},
+	{Name: "int64"}:   {Type: "integer", Format: "int64"},
+	{Name: "uint"}:    {Type: "integer", Format: "int64"},
+	{Name: "uint8"}:   {Type: "integer", Format: "int32"},
+	{Name: "byte"}:    {Type: "integer", Format: "int32"},
+	{Name: "uint16"}:  {Type: "integer", Format: "int32"},
+	{Name: "uint32"}:  {Type: "integer", Format: "int64"},
+	{Name: "uint64"}:  {Type: "integer", Format: "int64"},
+	{Name: "uintptr"}: {Type: "integer", Format: "int64"},
+	{Name: "float32"}: {Type: "number", Format: "float"},
+	{Name: "float64"}: {Type: "number", Format: "double"},
+
+	{Package: "time", Name: "Time"}:                                      {Type: "string", Format: "date-time"},
+	{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "Time"}:      {Type: "string", Format: "date-time"},
+	{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "MicroTime"}: {Type: "string", Format: "date-time"},
+	{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "Duration"}:  {Type: "string"},
+	{Package: "k8s.io/apimachinery/pkg/api/resource", Name: "Quantity"}:  {Type: "string"},
+}
+
+// Builder builds the schemas of go types from their gengo members, the
+// fields are named and required as encoding/json encodes them, except the
+// fields with a +default tag which are not required.
+type Builder struct {
+	// Inline inlines the schemas of the named struct types instead of
+	// referring to their schemas in Definitions.
+	Inline bool
+	// RefPrefix is the prefix of the references, e.g. "#/components/schemas/".
+	RefPrefix string
+	// Definitions contains the schemas of the referred named struct types.
+	Definitions map[string]*Schema
+	// Namer names the definitions, types of different packages with the same
+	// name are prefixed with their package name.
+	Namer namer.Namer
+
+	visiting    map[types.Name]bool
+	definitions map[types.Name]string
+}
+
+// NewBuilder returns a builder which refers to the named struct types with
+// refPrefix, an empty prefix inlines them.
+func NewBuilder(refPrefix string) *Builder {
+	return &Builder{
+		Inline:      len(refPrefix) == 0,
+		RefPrefix:   refPrefix,
+		Definitions: map[string]*Schema{},
+		Namer:       namer.NewPublicNamer(0),
+		visiting:    map[types.Name]bool{},
+		definitions: map[types.Name]string{},
+	}
+}
+
+// Schema returns the schema of t.
+func (b *Builder) Schema(t *types.Type) *Schema {
+	if s, ok := builtinSchemas[t.Name]; ok {
+		return &s
+	}
+	switch t.Kind {
+	case types.Builtin:
+		return &Schema{}
+	case types.Alias:
+		return b.Schema(t.Underlying)
+	case types.Pointer:
+		return b.Schema(t.Elem)
+	case types.Slice, types.Array:
+		if t.Elem.Name == types.Byte.Name {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: b.Schema(t.Elem)}
+	case types.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.Schema(t.Elem)}
+	case types.Struct:
+		return b.structRef(t)
+	}
+	// interfaces and the types which are not encoded by encoding/json accept any value
+	return &Schema{}
+}
+
+// structRef returns the schema of the struct t, named structs are referred
+// unless the builder inlines them.
+func (b *Builder) structRef(t *types.Type) *Schema {
+	if len(t.Name.Name) == 0 {
+		return b.structSchema(t)
+	}
+	if b.Inline {
+		// break the recursion of recursive types
+		if b.visiting[t.Name] {
+			return &Schema{Type: "object"}
+		}
+		b.visiting[t.Name] = true
+		defer delete(b.visiting, t.Name)
+		return b.structSchema(t)
+	}
+	name, ok := b.definitions[t.Name]
+	if !ok {
+		name = b.Namer.Name(t)
+		if _, used := b.Definitions[name]; used {
+			name = namer.NewPublicNamer(1).Name(t)
+		}
+		// reserve the name before the members refer to t
+		b.definitions[t.Name] = name
+		b.Definitions[name] = &Schema{}
+		b.Definitions[name] = b.structSchema(t)
+	}
+	return &Schema{Ref: b.RefPrefix + name}
+}
+
+// structSchema returns the object schema of the members of t, the members of
+// embedded structs without a json name are promoted like encoding/json does.
+func (b *Builder) structSchema(t *types.Type) *Schema {
+	s := &Schema{
+		Type:        "object",
+		Description: comment(t.CommentLines),
+		Properties:  map[string]*Schema{},
+	}
+	for _, m := range t.Members {
+		name, omitEmpty, ok := jsonName(m)
+		if !ok {
+			continue
+		}
+		if m.Embedded && len(name) == 0 {
+			embedded := m.Type
+			for embedded.Kind == types.Pointer || embedded.Kind == types.Alias {
+				if embedded.Kind == types.Pointer {
+					embedded = embedded.Elem
+				} else {
+					embedded = embedded.Underlying
+				}
+			}
+			if embedded.Kind == types.Struct {
+				promoted := b.structSchema(embedded)
+				for k, v := range promoted.Properties {
+					s.Properties[k] = v
+				}
+				s.Required = append(s.Required, promoted.Required...)
+				continue
+			}
+		}
+		if len(name) == 0 {
+			name = m.Name
+		}
+		property := b.Schema(m.Type)
+		if description := comment(m.CommentLines); len(description) != 0 && len(property.Ref) == 0 {
+			property.Description = description
+		}
+		s.Properties[name] = property
+		// the fields with a default value can be omitted by the clients
+		_, hasDefault, _ := tags.ParseDefaultTag(m.CommentLines)
+		if !omitEmpty && m.Type.Kind != types.Pointer && !hasDefault {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// jsonName returns the json name of the member and if it is omitted when
+// empty, ok is false for the members which are not encoded.
+func jsonName(m types.Member) (name string, omitEmpty bool, ok bool) {
+	if len(m.Name) == 0 || (!m.Embedded && strings.ToLower(m.Name[:1]) == m.Name[:1]) {
+		return "", false, false
+	}
+	tag := reflect.StructTag(m.Tags).Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	parts := strings.Split(tag, ",")
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty, true
+}
+
+// comment returns the doc comment without the tag lines.
+func comment(lines []string) string {
+	var ret []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "+") {
+			continue
+		}
+		ret = append(ret, line)
+	}
+	return strings.TrimSpace(strings.Join(ret, "\n"))
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openapi
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func TestRequired(t *testing.T) {
+	meta := &types.Type{
+		Name: types.Name{Package: "example.com/v1", Name: "Meta"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Name", Type: types.String, Tags: `json:"name"`},
+			{Name: "Labels", Type: &types.Type{Kind: types.Map, Key: types.String, Elem: types.String}, Tags: `json:"labels,omitempty"`},
+		},
+	}
+	widget := &types.Type{
+		Name: types.Name{Package: "example.com/v1", Name: "Widget"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Meta", Embedded: true, Type: meta, Tags: `json:",inline"`},
+			{Name: "Replicas", Type: types.Int32, Tags: `json:"replicas"`, CommentLines: []string{"+default=1"}},
+			{Name: "Order", Type: types.Int, Tags: `json:"order"`},
+			{Name: "Group", Type: types.String, Tags: `json:"group,omitempty"`},
+			{Name: "Paused", Type: &types.Type{Kind: types.Pointer, Elem: types.Bool}, Tags: `json:"paused"`, CommentLines: []string{"+default=false"}},
+			{Name: "Size", Type: &types.Type{Kind: types.Pointer, Elem: types.Int}, Tags: `json:"size"`},
+		},
+	}
+
+	s := NewBuilder("").Schema(widget)
+	expected := []string{"name", "order"}
+	if !reflect.DeepEqual(s.Required, expected) {
+		t.Errorf("expected the required fields %v, got %v", expected, s.Required)
+	}
+	if len(s.Properties) != 7 {
+		t.Errorf("expected 7 properties, got %v", s.Properties)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// genCRD generates the service of the crd backend, which stores the objects of
+// each type as a custom resource through the dynamic client.
+type genCRD struct {
+	generator.DefaultGen
+	outputPackage    string
+	imports          namer.ImportTracker
+	serviceGenerated bool
+	typesToGenerate  []*types.Type
+}
+
+var _ generator.Generator = &genCRD{}
+
+func (g *genCRD) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+// We only want to call GenerateType() once.
+func (g *genCRD) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.serviceGenerated
+	g.serviceGenerated = true
+	return ret
+}
+
+func (g *genCRD) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, "encoding/json")
+	imports = append(imports, "apierrors \"k8s.io/apimachinery/pkg/api/errors\"")
+	imports = append(imports, "metav1 \"k8s.io/apimachinery/pkg/apis/meta/v1\"")
+	imports = append(imports, "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured")
+	imports = append(imports, "k8s.io/apimachinery/pkg/runtime/schema")
+	imports = append(imports, "utiljson \"k8s.io/apimachinery/pkg/util/json\"")
+	imports = append(imports, filepath.Join(g.outputPackage, "server/errors"))
+	return
+}
+
+func (g *genCRD) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	m := map[string]interface{}{}
+
+	sw.Do(typeServiceStruct, m)
+	sw.Do(newServiceTmpl, m)
+	for _, t := range g.typesToGenerate {
+		tags, err := tags.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+		if err != nil {
+			return err
+		}
+		m := map[string]interface{}{
+			"type":       t,
+			"namespaced": !tags.NonNamespaced,
+			"namespace":  `""`,
+			"resource":   util.CustomResourceFor(c, t),
+			"nameKey":    util.MemberJSONName(t, "Name"),
+			"statusKey":  util.MemberJSONName(t, "Status"),
+		}
+		if !tags.NonNamespaced {
+			m["namespace"] = "namespace"
+		}
+		sw.Do(crdResourceTmpl, m)
+	}
+	sw.Do(unstructuredConversions, m)
+	sw.Do(kubeErrorTmpl, m)
+	return sw.Error()
+}
+
+var crdResourceTmpl = `
+// $.type|private$Resource is the custom resource of the $.type|private$ objects.
+var $.type|private$Resource = schema.GroupVersionResource{Group: "$.resource.Group$", Version: "$.resource.Version$", Resource: "$.resource.Plural$"}
+
+// toUnstructured$.type|public$ converts the $.type|private$ to the $.resource.Kind$ custom resource.
+func toUnstructured$.type|public$($if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) (*unstructured.Unstructured, error) {
+	return toUnstructured($.type|private$Resource, "$.resource.Kind$", $.namespace$, $.type|private$Obj.Name, $.type|private$Obj, "$.nameKey$", "$.statusKey$")
+}
+
+// fromUnstructured$.type|public$ converts the $.resource.Kind$ custom resource to the $.type|private$.
+func fromUnstructured$.type|public$(obj *unstructured.Unstructured) (*$.type|raw$, error) {
+	$.type|private$Obj := &$.type|raw${}
+	if err := fromUnstructured(obj, $.type|private$Obj, "$.nameKey$", "$.statusKey$"); err != nil {
+		return nil, err
+	}
+	return $.type|private$Obj, nil
+}
+`
+
+var unstructuredConversions = `
+// toUnstructured converts the object to a custom resource of the kind, the
+// member of nameKey is the name of the metadata, the member of statusKey is the
+// status and the other members are the spec.
+func toUnstructured(resource schema.GroupVersionResource, kind, namespace, name string, obj interface{}, nameKey, statusKey string) (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	// the numbers are decoded to int64 or float64 like the kubernetes api does
+	spec := map[string]interface{}{}
+	if err := utiljson.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	delete(spec, nameKey)
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	if status, ok := spec[statusKey]; ok {
+		delete(spec, statusKey)
+		u.Object["status"] = status
+	}
+	u.SetAPIVersion(resource.GroupVersion().String())
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u, nil
+}
+
+// fromUnstructured converts the custom resource to the object, it is the
+// inverse of toUnstructured.
+func fromUnstructured(u *unstructured.Unstructured, obj interface{}, nameKey, statusKey string) error {
+	content := map[string]interface{}{}
+	if spec, ok := u.Object["spec"].(map[string]interface{}); ok {
+		for k, v := range spec {
+			content[k] = v
+		}
+	}
+	if status, ok := u.Object["status"]; ok && len(statusKey) != 0 {
+		content[statusKey] = status
+	}
+	content[nameKey] = u.GetName()
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, obj)
+}
+`
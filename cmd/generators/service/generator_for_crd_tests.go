@@ -0,0 +1,178 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// genCRDTest generates the tests of the service of a type of the crd backend,
+// they run the service against the fake dynamic client of client-go.
+type genCRDTest struct {
+	generator.DefaultGen
+	outputPackage    string
+	imports          namer.ImportTracker
+	serviceGenerated bool
+	typeToGenerate   *types.Type
+}
+
+var _ generator.Generator = &genCRDTest{}
+
+// FileType makes the tests a scaffold, they are modified with the service.
+func (g *genCRDTest) FileType() string { return util.ScaffoldFileType }
+
+func (g *genCRDTest) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+// We only want to call GenerateType() once.
+func (g *genCRDTest) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.serviceGenerated
+	g.serviceGenerated = true
+	return ret
+}
+
+func (g *genCRDTest) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, "context")
+	imports = append(imports, "reflect")
+	imports = append(imports, "testing")
+	imports = append(imports, "k8s.io/apimachinery/pkg/runtime")
+	imports = append(imports, "k8s.io/client-go/dynamic/fake")
+	imports = append(imports, filepath.Join(g.outputPackage, "server/errors"))
+	return
+}
+
+func (g *genCRDTest) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	tags, err := tags.ParseClientGenTags(append(g.typeToGenerate.SecondClosestCommentLines, g.typeToGenerate.CommentLines...))
+	if err != nil {
+		return err
+	}
+
+	m := map[string]interface{}{
+		"type":       g.typeToGenerate,
+		"namespaced": !tags.NonNamespaced,
+		"resource":   util.CustomResourceFor(c, g.typeToGenerate),
+		"get":        tags.HasVerb("get"),
+		"list":       tags.HasVerb("list"),
+		"update":     tags.HasVerb("update"),
+		"delete":     tags.HasVerb("delete"),
+	}
+
+	sw.Do(conversionCRDTest, m)
+	// the other verbs are tested with the created object
+	if tags.HasVerb("create") {
+		sw.Do(serviceCRDTest, m)
+	}
+	return sw.Error()
+}
+
+var conversionCRDTest = `
+// Test$.type|public$Conversion tests the conversion of the $.type|private$ to the custom resource and back.
+// TODO(user): Set the fields of your type to test their conversion.
+func Test$.type|public$Conversion(t *testing.T) {
+	$.type|private$Obj := &$.type|raw${}
+	$.type|private$Obj.Name = "test"
+
+	obj, err := toUnstructured$.type|public$($if .namespaced$"default", $end$$.type|private$Obj)
+	if err != nil {
+		t.Fatalf("toUnstructured$.type|public$ failed with:%v", err)
+	}
+	if obj.GetAPIVersion() != "$.resource.String$" || obj.GetKind() != "$.resource.Kind$" || obj.GetName() != "test"$if .namespaced$ || obj.GetNamespace() != "default"$end$ {
+		t.Errorf("unexpected custom resource %v", obj)
+	}
+
+	converted, err := fromUnstructured$.type|public$(obj)
+	if err != nil {
+		t.Fatalf("fromUnstructured$.type|public$ failed with:%v", err)
+	}
+	if !reflect.DeepEqual(converted, $.type|private$Obj) {
+		t.Errorf("expected %+v, got %+v", $.type|private$Obj, converted)
+	}
+}
+`
+
+var serviceCRDTest = `
+// Test$.type|public$Service tests the service of the $.type|private$ against the fake dynamic client.
+// TODO(user): Modify this function to test your logic.
+func Test$.type|public$Service(t *testing.T) {
+	ctx := context.Background()
+	s := New(&Options{DynamicClient: fake.NewSimpleDynamicClient(runtime.NewScheme())})
+
+	$.type|private$Obj := &$.type|raw${}
+	$.type|private$Obj.Name = "test"
+	created, err := s.Create$.type|public$(ctx, $if .namespaced$"default", $end$$.type|private$Obj)
+	if err != nil {
+		t.Fatalf("Create$.type|public$ failed with:%v", err)
+	}
+	if created.Name != "test" {
+		t.Errorf("expected the name %q, got %q", "test", created.Name)
+	}
+	if _, err := s.Create$.type|public$(ctx, $if .namespaced$"default", $end$$.type|private$Obj); !errors.IsAlreadyExists(err) {
+		t.Errorf("expected an already exists error, got %v", err)
+	}
+$- if .get$
+
+	got, err := s.Get$.type|public$(ctx, $if .namespaced$"default", $end$"test")
+	if err != nil {
+		t.Fatalf("Get$.type|public$ failed with:%v", err)
+	}
+	if !reflect.DeepEqual(got, created) {
+		t.Errorf("expected %+v, got %+v", created, got)
+	}
+$- end$
+$- if .list$
+
+	list, err := s.List$.type|public$(ctx, $if .namespaced$"default", $end$ListOptions{})
+	if err != nil {
+		t.Fatalf("List$.type|public$ failed with:%v", err)
+	}
+	if len(list.Items) != 1 {
+		t.Errorf("expected 1 object, got %d", len(list.Items))
+	}
+$- end$
+$- if .update$
+
+	if err := s.Update$.type|public$(ctx, $if .namespaced$"default", $end$created); err != nil {
+		t.Errorf("Update$.type|public$ failed with:%v", err)
+	}
+$- end$
+$- if .delete$
+
+	if err := s.Delete$.type|public$(ctx, $if .namespaced$"default", $end$"test"); err != nil {
+		t.Fatalf("Delete$.type|public$ failed with:%v", err)
+	}
+$- if .get$
+	if _, err := s.Get$.type|public$(ctx, $if .namespaced$"default", $end$"test"); !errors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+$- end$
+$- end$
+}
+`
@@ -0,0 +1,309 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"io"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// genCRDService generates the service of a type which stores the objects as a
+// custom resource through the dynamic client.
+type genCRDService struct {
+	generator.DefaultGen
+	outputPackage    string
+	imports          namer.ImportTracker
+	serviceGenerated bool
+	typeToGenerate   *types.Type
+}
+
+var _ generator.Generator = &genCRDService{}
+
+// FileType makes the service of the type a scaffold, the user implements it.
+func (g *genCRDService) FileType() string { return util.ScaffoldFileType }
+
+func (g *genCRDService) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+// We only want to call GenerateType() once.
+func (g *genCRDService) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.serviceGenerated
+	g.serviceGenerated = true
+	return ret
+}
+
+func (g *genCRDService) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, "context")
+	imports = append(imports, "k8s.io/klog")
+	imports = append(imports, "metav1 \"k8s.io/apimachinery/pkg/apis/meta/v1\"")
+	imports = append(imports, "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured")
+	return
+}
+
+func (g *genCRDService) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	klog.Infof("processing type %v", g.typeToGenerate)
+	tags, err := tags.ParseClientGenTags(append(g.typeToGenerate.SecondClosestCommentLines, g.typeToGenerate.CommentLines...))
+	if err != nil {
+		return err
+	}
+
+	hasStatus := util.HasStatus(g.typeToGenerate, tags)
+	m := map[string]interface{}{
+		"type":       g.typeToGenerate,
+		"namespaced": !tags.NonNamespaced,
+		"keepStatus": hasStatus && tags.HasVerb("updateStatus"),
+	}
+
+	if tags.HasVerb("create") {
+		sw.Do(createCRDService, m)
+	}
+	if tags.HasVerb("get") {
+		sw.Do(getCRDService, m)
+	}
+	if tags.HasVerb("list") {
+		sw.Do(listCRDService, m)
+	}
+	if tags.HasVerb("watch") {
+		sw.Do(watchCRDService, m)
+	}
+	if tags.HasVerb("update") {
+		sw.Do(updateCRDService, m)
+	}
+	if tags.HasVerb("delete") {
+		sw.Do(deleteCRDService, m)
+	}
+	if hasStatus && tags.HasVerb("get") {
+		sw.Do(getStatusCRDService, m)
+	}
+	if hasStatus && tags.HasVerb("updateStatus") {
+		sw.Do(updateStatusCRDService, m)
+	}
+	for _, e := range util.Extensions(c, g.typeToGenerate, tags) {
+		m["ext"] = e
+		sw.Do(extensionObjectService, m)
+	}
+	return sw.Error()
+}
+
+var createCRDService = `
+// Create$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects as custom resources.
+func (s *service) Create$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) (*$.type|raw$, error) {
+	client := s.opt.DynamicClient.Resource($.type|private$Resource)$if .namespaced$.Namespace(namespace)$end$
+	obj, err := toUnstructured$.type|public$($if .namespaced$namespace, $end$$.type|private$Obj)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.Create(obj, metav1.CreateOptions{})
+	if err != nil {
+		klog.Errorf("create $.type|private$ failed with:%v", err)
+		return nil, kubeError(err)
+	}
+	return fromUnstructured$.type|public$(created)
+}
+`
+
+var getCRDService = `
+// Get$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects as custom resources.
+func (s *service) Get$.type|public$(ctx context.Context, $if .namespaced$namespace, $end$name string) (*$.type|raw$, error) {
+	client := s.opt.DynamicClient.Resource($.type|private$Resource)$if .namespaced$.Namespace(namespace)$end$
+
+	obj, err := client.Get(name, metav1.GetOptions{})
+	if err != nil {
+		klog.Errorf("get $.type|private$ %v failed with:%v", name, err)
+		return nil, kubeError(err)
+	}
+	return fromUnstructured$.type|public$(obj)
+}
+`
+
+var listCRDService = `
+// List$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects as custom resources.
+func (s *service) List$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$opts ListOptions) (*$.type|public$List, error) {
+	client := s.opt.DynamicClient.Resource($.type|private$Resource)$if .namespaced$.Namespace(namespace)$end$
+
+	objList, err := client.List(metav1.ListOptions{
+		Limit:    opts.Limit,
+		Continue: opts.Continue,
+	})
+	if err != nil {
+		klog.Errorf("list $.type|allLowercasePlural$ failed with:%v", err)
+		return nil, kubeError(err)
+	}
+
+	list := &$.type|public$List{Items: make([]$.type|raw$, 0, len(objList.Items)), Continue: objList.GetContinue()}
+	for i := range objList.Items {
+		$.type|private$Obj, err := fromUnstructured$.type|public$(&objList.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, *$.type|private$Obj)
+	}
+	return list, nil
+}
+`
+
+var watchCRDService = `
+// Watch$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects as custom resources.
+// The events channel must be closed when ctx is done.
+func (s *service) Watch$.type|public$(ctx context.Context$if .namespaced$, namespace string$end$) (<-chan WatchEvent, error) {
+	client := s.opt.DynamicClient.Resource($.type|private$Resource)$if .namespaced$.Namespace(namespace)$end$
+
+	watcher, err := client.Watch(metav1.ListOptions{})
+	if err != nil {
+		klog.Errorf("watch $.type|allLowercasePlural$ failed with:%v", err)
+		return nil, kubeError(err)
+	}
+
+	events := make(chan WatchEvent)
+	go func() {
+		defer close(events)
+		defer watcher.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.ResultChan():
+				if !ok {
+					return
+				}
+				// the errors of the watch are not custom resources
+				obj, ok := event.Object.(*unstructured.Unstructured)
+				if !ok {
+					continue
+				}
+				$.type|private$Obj, err := fromUnstructured$.type|public$(obj)
+				if err != nil {
+					klog.Errorf("convert $.type|private$ %v failed with:%v", obj.GetName(), err)
+					continue
+				}
+				select {
+				case events <- WatchEvent{Type: EventType(event.Type), Object: $.type|private$Obj}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+`
+
+var updateCRDService = `
+// Update$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects as custom resources.
+func (s *service) Update$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) error {
+	client := s.opt.DynamicClient.Resource($.type|private$Resource)$if .namespaced$.Namespace(namespace)$end$
+
+	current, err := client.Get($.type|private$Obj.Name, metav1.GetOptions{})
+	if err != nil {
+		klog.Errorf("get $.type|private$ %v failed with:%v", $.type|private$Obj.Name, err)
+		return kubeError(err)
+	}
+	obj, err := toUnstructured$.type|public$($if .namespaced$namespace, $end$$.type|private$Obj)
+	if err != nil {
+		return err
+	}
+	// the resource version of the current object rejects concurrent updates
+	obj.SetResourceVersion(current.GetResourceVersion())
+$- if .keepStatus$
+	// the status is only written by Update$.type|public$Status
+	if status, ok := current.Object["status"]; ok {
+		obj.Object["status"] = status
+	} else {
+		delete(obj.Object, "status")
+	}
+$- end$
+
+	if _, err := client.Update(obj, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("update $.type|private$ failed with:%v", err)
+		return kubeError(err)
+	}
+	return nil
+}
+`
+
+var deleteCRDService = `
+// Delete$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects as custom resources.
+func (s *service) Delete$.type|public$(ctx context.Context, $if .namespaced$namespace, $end$name string) error {
+	client := s.opt.DynamicClient.Resource($.type|private$Resource)$if .namespaced$.Namespace(namespace)$end$
+
+	if err := client.Delete(name, &metav1.DeleteOptions{}); err != nil {
+		klog.Errorf("delete $.type|private$ %v failed with:%v", name, err)
+		return kubeError(err)
+	}
+	return nil
+}
+`
+
+var getStatusCRDService = `
+// Get$.type|public$Status xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects as custom resources.
+func (s *service) Get$.type|public$Status(ctx context.Context, $if .namespaced$namespace, $end$name string) (*$.type|raw$, error) {
+	client := s.opt.DynamicClient.Resource($.type|private$Resource)$if .namespaced$.Namespace(namespace)$end$
+
+	obj, err := client.Get(name, metav1.GetOptions{})
+	if err != nil {
+		klog.Errorf("get $.type|private$ %v status failed with:%v", name, err)
+		return nil, kubeError(err)
+	}
+	return fromUnstructured$.type|public$(obj)
+}
+`
+
+var updateStatusCRDService = `
+// Update$.type|public$Status xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects as custom resources.
+func (s *service) Update$.type|public$Status(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) error {
+	client := s.opt.DynamicClient.Resource($.type|private$Resource)$if .namespaced$.Namespace(namespace)$end$
+
+	current, err := client.Get($.type|private$Obj.Name, metav1.GetOptions{})
+	if err != nil {
+		klog.Errorf("get $.type|private$ %v failed with:%v", $.type|private$Obj.Name, err)
+		return kubeError(err)
+	}
+	obj, err := toUnstructured$.type|public$($if .namespaced$namespace, $end$$.type|private$Obj)
+	if err != nil {
+		return err
+	}
+	current.Object["status"] = obj.Object["status"]
+
+	if _, err := client.UpdateStatus(current, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("update $.type|private$ status failed with:%v", err)
+		return kubeError(err)
+	}
+	return nil
+}
+`
@@ -19,6 +19,9 @@ package service
 import (
 	"io"
 
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/namer"
 	"k8s.io/gengo/types"
@@ -29,11 +32,11 @@ import (
 type genServiceInterface struct {
 	generator.DefaultGen
 	clientsetPackage string
-	inputPackages    []string
 	outputPackage    string
 	imports          namer.ImportTracker
 	serviceGenerated bool
 	typesToGenerate  []*types.Type
+	backend          util.Backend
 }
 
 var _ generator.Generator = &genServiceInterface{}
@@ -53,12 +56,7 @@ func (g *genServiceInterface) Filter(c *generator.Context, t *types.Type) bool {
 
 func (g *genServiceInterface) Imports(c *generator.Context) (imports []string) {
 	imports = append(imports, g.imports.ImportLines()...)
-	imports = append(imports, "k8s.io/client-go/kubernetes")
-	imports = append(imports, "apiv1 \"k8s.io/api/core/v1\"")
-
-	for _, pkg := range g.inputPackages {
-		imports = append(imports, pkg)
-	}
+	imports = append(imports, g.backend.OptionImports()...)
 	return
 }
 
@@ -67,45 +65,143 @@ func (g *genServiceInterface) GenerateType(c *generator.Context, t *types.Type,
 
 	klog.Infof("processing type %v", t)
 	m := map[string]interface{}{
-		"types": g.typesToGenerate,
+		"types":   g.typesToGenerate,
+		"backend": g.backend,
 	}
 
 	sw.Do(typeOptionsStruct, m)
-	sw.Do(typeServiceStruct, m)
-	sw.Do(newServiceTmpl, m)
+	sw.Do(typeListOptionsStruct, m)
+	sw.Do(typeWatchEventStruct, m)
+	for _, t := range g.typesToGenerate {
+		tags, err := tags.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+		if err != nil {
+			return err
+		}
+		if tags.HasVerb("list") {
+			sw.Do(typeListStruct, map[string]interface{}{"type": t})
+		}
+	}
+
 	sw.Do(serviceInterfaceTmpl, m)
+	for _, t := range g.typesToGenerate {
+		tags, err := tags.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+		if err != nil {
+			return err
+		}
+		m := map[string]interface{}{
+			"type":       t,
+			"namespaced": !tags.NonNamespaced,
+		}
+		if tags.HasVerb("create") {
+			sw.Do(createMethodTmpl, m)
+		}
+		if tags.HasVerb("get") {
+			sw.Do(getMethodTmpl, m)
+		}
+		if tags.HasVerb("list") {
+			sw.Do(listMethodTmpl, m)
+		}
+		if tags.HasVerb("watch") {
+			sw.Do(watchMethodTmpl, m)
+		}
+		if tags.HasVerb("update") {
+			sw.Do(updateMethodTmpl, m)
+		}
+		if tags.HasVerb("delete") {
+			sw.Do(deleteMethodTmpl, m)
+		}
+		hasStatus := util.HasStatus(t, tags)
+		if hasStatus && tags.HasVerb("get") {
+			sw.Do(getStatusMethodTmpl, m)
+		}
+		if hasStatus && tags.HasVerb("updateStatus") {
+			sw.Do(updateStatusMethodTmpl, m)
+		}
+		for _, e := range util.Extensions(c, t, tags) {
+			m["ext"] = e
+			sw.Do(extensionMethodTmpl, m)
+		}
+	}
+	sw.Do("}\n", m)
 	return sw.Error()
 }
 
 var typeOptionsStruct = `
 // Options contains the config by service
 type Options struct {
-	KubeClientset kubernetes.Interface
+$- range .backend.Options$
+	$.Name$ $.Type$
+$- end$
 }
 `
 
-var typeServiceStruct = `
-// service implements the Service interface.
-type service struct {
-	opt *Options
+var typeListOptionsStruct = `
+// ListOptions contains the pagination options of the list methods.
+type ListOptions struct {
+	// Limit is the maximum number of objects to return, zero means no limit.
+	Limit int64
+	// Continue is the token returned by the previous list call to get the next page.
+	Continue string
 }
 `
 
-var newServiceTmpl = `
-// New is create a service object.
-func New(opt *Options) Interface {
-	return &service{opt: opt}
+var typeWatchEventStruct = `
+// EventType defines the possible types of watch events.
+type EventType string
+
+const (
+	// Added is sent when an object is created.
+	Added EventType = "ADDED"
+	// Modified is sent when an object is updated.
+	Modified EventType = "MODIFIED"
+	// Deleted is sent when an object is deleted.
+	Deleted EventType = "DELETED"
+)
+
+// WatchEvent is a change of an object sent by the watch methods.
+type WatchEvent struct {
+	Type   EventType` + "    `json:\"type\"`" + `
+	Object interface{}` + "    `json:\"object\"`" + `
+}
+`
+
+var typeListStruct = `
+// $.type|public$List is the result of List$.type|public$.
+type $.type|public$List struct {
+	Items    []$.type|raw$` + "    `json:\"items\"`" + `
+	// Continue is set if there are more objects, pass it in ListOptions to get them.
+	Continue string` + "    `json:\"continue,omitempty\"`" + `
 }
 `
 
 var serviceInterfaceTmpl = `
 // Interface is definition service all method.
 type Interface interface {
-$range .types$
-	Create$.|public$(ctx context.Context, $.|private$Obj *types.$.|public$) error
-	Get$.|public$(ctx context.Context, name string) (*apiv1.$.|public$, error)
-	Update$.|public$(ctx context.Context, $.|private$Obj *types.$.|public$) error
-	Delete$.|public$(ctx context.Context, name string) error
-$end$
-}
+`
+
+var createMethodTmpl = `Create$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) (*$.type|raw$, error)
+`
+
+var getMethodTmpl = `Get$.type|public$(ctx context.Context, $if .namespaced$namespace, $end$name string) (*$.type|raw$, error)
+`
+
+var listMethodTmpl = `List$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$opts ListOptions) (*$.type|public$List, error)
+`
+
+var watchMethodTmpl = `Watch$.type|public$(ctx context.Context$if .namespaced$, namespace string$end$) (<-chan WatchEvent, error)
+`
+
+var updateMethodTmpl = `Update$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) error
+`
+
+var deleteMethodTmpl = `Delete$.type|public$(ctx context.Context, $if .namespaced$namespace, $end$name string) error
+`
+
+var getStatusMethodTmpl = `Get$.type|public$Status(ctx context.Context, $if .namespaced$namespace, $end$name string) (*$.type|raw$, error)
+`
+
+var updateStatusMethodTmpl = `Update$.type|public$Status(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) error
+`
+
+var extensionMethodTmpl = `$.ext.Method$(ctx context.Context, $if .namespaced$namespace, $end$name string$if or (.ext.HasVerb "create") (.ext.HasVerb "update")$, input *$.ext.Input$$else if .ext.HasVerb "patch"$, data []byte$end$) (*$.ext.Result$, error)
 `
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"io"
+	"path/filepath"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// genKubernetes generates the service of the kubernetes backend, which stores
+// the objects of each type as the core kind of the same name.
+type genKubernetes struct {
+	generator.DefaultGen
+	outputPackage    string
+	imports          namer.ImportTracker
+	serviceGenerated bool
+}
+
+var _ generator.Generator = &genKubernetes{}
+
+func (g *genKubernetes) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+// We only want to call GenerateType() once.
+func (g *genKubernetes) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.serviceGenerated
+	g.serviceGenerated = true
+	return ret
+}
+
+func (g *genKubernetes) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, "apierrors \"k8s.io/apimachinery/pkg/api/errors\"")
+	imports = append(imports, "metav1 \"k8s.io/apimachinery/pkg/apis/meta/v1\"")
+	imports = append(imports, filepath.Join(g.outputPackage, "server/errors"))
+	return
+}
+
+func (g *genKubernetes) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	m := map[string]interface{}{}
+
+	sw.Do(typeServiceStruct, m)
+	sw.Do(newServiceTmpl, m)
+	sw.Do(kubeErrorTmpl, m)
+	return sw.Error()
+}
+
+var typeServiceStruct = `
+// service implements the Service interface.
+type service struct {
+	opt *Options
+}
+`
+
+var newServiceTmpl = `
+// New is create a service object.
+func New(opt *Options) Interface {
+	return &service{opt: opt}
+}
+`
+
+var kubeErrorTmpl = `
+// kubeError converts the errors of the kubernetes api to the errors returned by the service.
+func kubeError(err error) error {
+	var reason errors.Reason
+	switch apierrors.ReasonForError(err) {
+	case metav1.StatusReasonNotFound:
+		reason = errors.ReasonNotFound
+	case metav1.StatusReasonAlreadyExists:
+		reason = errors.ReasonAlreadyExists
+	case metav1.StatusReasonConflict:
+		reason = errors.ReasonConflict
+	case metav1.StatusReasonInvalid:
+		reason = errors.ReasonInvalid
+	case metav1.StatusReasonForbidden:
+		reason = errors.ReasonForbidden
+	case metav1.StatusReasonServiceUnavailable:
+		reason = errors.ReasonUnavailable
+	default:
+		return err
+	}
+	return &errors.StatusError{Reason: reason, Message: err.Error()}
+}
+`
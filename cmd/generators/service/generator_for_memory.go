@@ -0,0 +1,216 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"io"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// genMemoryService generates the service of a type which keeps the objects in
+// the in-memory store of the type.
+type genMemoryService struct {
+	generator.DefaultGen
+	outputPackage    string
+	imports          namer.ImportTracker
+	serviceGenerated bool
+	typeToGenerate   *types.Type
+}
+
+var _ generator.Generator = &genMemoryService{}
+
+// FileType makes the service of the type a scaffold, the user implements it.
+func (g *genMemoryService) FileType() string { return util.ScaffoldFileType }
+
+func (g *genMemoryService) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+// We only want to call GenerateType() once.
+func (g *genMemoryService) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.serviceGenerated
+	g.serviceGenerated = true
+	return ret
+}
+
+func (g *genMemoryService) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, "context")
+	return
+}
+
+func (g *genMemoryService) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	klog.Infof("processing type %v", g.typeToGenerate)
+	tags, err := tags.ParseClientGenTags(append(g.typeToGenerate.SecondClosestCommentLines, g.typeToGenerate.CommentLines...))
+	if err != nil {
+		return err
+	}
+
+	hasStatus := util.HasStatus(g.typeToGenerate, tags)
+	m := map[string]interface{}{
+		"type":       g.typeToGenerate,
+		"namespaced": !tags.NonNamespaced,
+		// cluster-scoped objects are stored without a namespace
+		"namespace":  `""`,
+		"keepStatus": hasStatus && tags.HasVerb("updateStatus"),
+	}
+	if !tags.NonNamespaced {
+		m["namespace"] = "namespace"
+	}
+
+	if tags.HasVerb("create") {
+		sw.Do(createMemoryService, m)
+	}
+	if tags.HasVerb("get") {
+		sw.Do(getMemoryService, m)
+	}
+	if tags.HasVerb("list") {
+		sw.Do(listMemoryService, m)
+	}
+	if tags.HasVerb("watch") {
+		sw.Do(watchMemoryService, m)
+	}
+	if tags.HasVerb("update") {
+		sw.Do(updateMemoryService, m)
+	}
+	if tags.HasVerb("delete") {
+		sw.Do(deleteMemoryService, m)
+	}
+	if hasStatus && tags.HasVerb("get") {
+		sw.Do(getStatusMemoryService, m)
+	}
+	if hasStatus && tags.HasVerb("updateStatus") {
+		sw.Do(updateStatusMemoryService, m)
+	}
+	for _, e := range util.Extensions(c, g.typeToGenerate, tags) {
+		m["ext"] = e
+		sw.Do(extensionObjectService, m)
+	}
+	return sw.Error()
+}
+
+var createMemoryService = `
+// Create$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) Create$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) (*$.type|raw$, error) {
+	created, err := s.$.type|allLowercasePlural$.create($.namespace$, $.type|private$Obj.Name, $.type|private$Obj)
+	if err != nil {
+		return nil, err
+	}
+	return created.(*$.type|raw$), nil
+}
+`
+
+var getMemoryService = `
+// Get$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) Get$.type|public$(ctx context.Context, $if .namespaced$namespace, $end$name string) (*$.type|raw$, error) {
+	obj, err := s.$.type|allLowercasePlural$.get($.namespace$, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*$.type|raw$), nil
+}
+`
+
+var listMemoryService = `
+// List$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) List$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$opts ListOptions) (*$.type|public$List, error) {
+	objects, next, err := s.$.type|allLowercasePlural$.list($.namespace$, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &$.type|public$List{Items: make([]$.type|raw$, 0, len(objects)), Continue: next}
+	for _, obj := range objects {
+		list.Items = append(list.Items, *obj.(*$.type|raw$))
+	}
+	return list, nil
+}
+`
+
+var watchMemoryService = `
+// Watch$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+// The events channel must be closed when ctx is done.
+func (s *service) Watch$.type|public$(ctx context.Context$if .namespaced$, namespace string$end$) (<-chan WatchEvent, error) {
+	return s.$.type|allLowercasePlural$.watch(ctx, $.namespace$), nil
+}
+`
+
+var updateMemoryService = `
+// Update$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) Update$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) error {
+	_, err := s.$.type|allLowercasePlural$.update($.namespace$, $.type|private$Obj.Name, func(current interface{}) interface{} {
+$- if .keepStatus$
+		// the status is only written by Update$.type|public$Status
+		updated := *$.type|private$Obj
+		updated.Status = current.(*$.type|raw$).Status
+		return &updated
+$- else$
+		return $.type|private$Obj
+$- end$
+	})
+	return err
+}
+`
+
+var deleteMemoryService = `
+// Delete$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) Delete$.type|public$(ctx context.Context, $if .namespaced$namespace, $end$name string) error {
+	return s.$.type|allLowercasePlural$.delete($.namespace$, name)
+}
+`
+
+var getStatusMemoryService = `
+// Get$.type|public$Status xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) Get$.type|public$Status(ctx context.Context, $if .namespaced$namespace, $end$name string) (*$.type|raw$, error) {
+	obj, err := s.$.type|allLowercasePlural$.get($.namespace$, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*$.type|raw$), nil
+}
+`
+
+var updateStatusMemoryService = `
+// Update$.type|public$Status xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in memory.
+func (s *service) Update$.type|public$Status(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) error {
+	_, err := s.$.type|allLowercasePlural$.update($.namespace$, $.type|private$Obj.Name, func(current interface{}) interface{} {
+		updated := current.(*$.type|raw$)
+		updated.Status = $.type|private$Obj.Status
+		return updated
+	})
+	return err
+}
+`
@@ -0,0 +1,417 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// genSQL generates the service of the sql backend, which stores the objects of
+// each type in a table of a database/sql database.
+type genSQL struct {
+	generator.DefaultGen
+	outputPackage    string
+	imports          namer.ImportTracker
+	serviceGenerated bool
+	typesToGenerate  []*types.Type
+}
+
+var _ generator.Generator = &genSQL{}
+
+func (g *genSQL) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+// We only want to call GenerateType() once.
+func (g *genSQL) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.serviceGenerated
+	g.serviceGenerated = true
+	return ret
+}
+
+func (g *genSQL) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, "context")
+	imports = append(imports, "database/sql")
+	imports = append(imports, "encoding/base64")
+	imports = append(imports, "encoding/json")
+	imports = append(imports, "fmt")
+	imports = append(imports, "math")
+	imports = append(imports, "sync")
+	imports = append(imports, filepath.Join(g.outputPackage, "server/errors"))
+	return
+}
+
+// sqlTable is the table of a type.
+type sqlTable struct {
+	Type       *types.Type
+	Name       string
+	Namespaced bool
+	// Columns are the columns of the spec and the status.
+	Columns []sqlColumn
+	// Spec are the columns of the members but the status.
+	Spec []sqlColumn
+	// Status is the column of the "Status" member, if the type has one.
+	Status *sqlColumn
+	// HasStatus is true if the status is the status of a status sub-resource,
+	// which is not written by the update of the spec.
+	HasStatus bool
+}
+
+// newSQLTable returns the table of the type t named after the lowercase plural.
+func newSQLTable(c *generator.Context, t *types.Type) (*sqlTable, error) {
+	tags, err := tags.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+	if err != nil {
+		return nil, err
+	}
+	spec, status := sqlColumns(t)
+	columns := spec
+	if status != nil {
+		// the status is the last column
+		columns = append(columns[:len(columns):len(columns)], *status)
+	}
+	return &sqlTable{
+		Type:       t,
+		Name:       c.Namers["allLowercasePlural"].Name(t),
+		Namespaced: !tags.NonNamespaced,
+		Columns:    columns,
+		Spec:       spec,
+		Status:     status,
+		HasStatus:  status != nil && util.HasStatus(t, tags) && tags.HasVerb("updateStatus"),
+	}, nil
+}
+
+// keys returns the quoted columns of the primary key.
+func (t *sqlTable) keys() []string {
+	if t.Namespaced {
+		return []string{quoteIdent("namespace"), quoteIdent("name")}
+	}
+	return []string{quoteIdent("name")}
+}
+
+// names returns the quoted names of the columns, without the primary key.
+func (t *sqlTable) names(columns []sqlColumn) []string {
+	var names []string
+	for _, c := range columns {
+		names = append(names, quoteIdent(c.Name))
+	}
+	return names
+}
+
+// table returns the quoted name of the table.
+func (t *sqlTable) table() string {
+	return quoteIdent(t.Name)
+}
+
+// where returns the condition which selects an object by the primary key.
+func (t *sqlTable) where() string {
+	return " WHERE " + strings.Join(assignments(t.keys()), " AND ")
+}
+
+// DDL returns the statement which creates the table.
+func (t *sqlTable) DDL() string {
+	var lines []string
+	for _, key := range t.keys() {
+		lines = append(lines, key+" VARCHAR(253) NOT NULL")
+	}
+	for _, c := range t.Columns {
+		lines = append(lines, quoteIdent(c.Name)+" "+c.Type+" NOT NULL")
+	}
+	lines = append(lines, "PRIMARY KEY ("+strings.Join(t.keys(), ", ")+")")
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n\t%s\n)", t.table(), strings.Join(lines, ",\n\t"))
+}
+
+// Insert returns the query which inserts an object, the arguments are the
+// primary key and the values of the columns.
+func (t *sqlTable) Insert() string {
+	names := append(t.keys(), t.names(t.Columns)...)
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", t.table(), strings.Join(names, ", "), placeholders(len(names)))
+}
+
+// Select returns the query which selects the name and the columns of an object
+// by the primary key.
+func (t *sqlTable) Select() string {
+	return fmt.Sprintf("SELECT %s FROM %s%s", strings.Join(append([]string{quoteIdent("name")}, t.names(t.Columns)...), ", "), t.table(), t.where())
+}
+
+// List returns the query which selects the objects of a namespace ordered by
+// name, the arguments are the namespace, the first name and the limit.
+func (t *sqlTable) List() string {
+	where := ` WHERE "name" >= ?`
+	if t.Namespaced {
+		where = ` WHERE "namespace" = ? AND "name" >= ?`
+	}
+	return fmt.Sprintf(`SELECT %s FROM %s%s ORDER BY "name" LIMIT ?`, strings.Join(append([]string{quoteIdent("name")}, t.names(t.Columns)...), ", "), t.table(), where)
+}
+
+// Update returns the query which updates the columns of an object, but the
+// status of a status sub-resource.
+func (t *sqlTable) Update() string {
+	names := t.names(t.Columns)
+	if t.HasStatus {
+		names = t.names(t.Spec)
+	}
+	set := strings.Join(assignments(names), ", ")
+	if len(names) == 0 {
+		// there is nothing to update but the query tells if the object exists
+		set = `"name" = "name"`
+	}
+	return fmt.Sprintf("UPDATE %s SET %s%s", t.table(), set, t.where())
+}
+
+// UpdateStatus returns the query which updates the status of an object.
+func (t *sqlTable) UpdateStatus() string {
+	if !t.HasStatus {
+		return ""
+	}
+	return fmt.Sprintf("UPDATE %s SET %s = ?%s", t.table(), quoteIdent(t.Status.Name), t.where())
+}
+
+// Delete returns the query which deletes an object.
+func (t *sqlTable) Delete() string {
+	return fmt.Sprintf("DELETE FROM %s%s", t.table(), t.where())
+}
+
+// assignments returns "<column> = ?" for every quoted column.
+func assignments(columns []string) []string {
+	var ret []string
+	for _, c := range columns {
+		ret = append(ret, c+" = ?")
+	}
+	return ret
+}
+
+// placeholders returns n comma separated placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func (g *genSQL) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	var tables []*sqlTable
+	for _, t := range g.typesToGenerate {
+		table, err := newSQLTable(c, t)
+		if err != nil {
+			return err
+		}
+		tables = append(tables, table)
+	}
+	m := map[string]interface{}{
+		"types":  g.typesToGenerate,
+		"tables": tables,
+	}
+
+	sw.Do(typeSQLServiceStruct, m)
+	sw.Do(newSQLServiceTmpl, m)
+	sw.Do(sqlSchemaTmpl, m)
+	sw.Do(sqlStmtFunc, m)
+	sw.Do(sqlContinueFuncs, m)
+	for _, table := range tables {
+		sw.Do(sqlTableTmpl, map[string]interface{}{"type": table.Type, "table": table})
+	}
+	return sw.Error()
+}
+
+var typeSQLServiceStruct = `
+// service implements the Service interface, the objects of each type are
+// stored in a table of the database.
+type service struct {
+	opt *Options
+
+	mu    sync.Mutex
+	stmts map[string]*sql.Stmt
+$- range .types$
+	$.|allLowercasePlural$ *watchers
+$- end$
+}
+`
+
+var newSQLServiceTmpl = `
+// New is create a service object which stores the objects in opt.DB.
+func New(opt *Options) Interface {
+	return &service{
+		opt:   opt,
+		stmts: map[string]*sql.Stmt{},
+$- range .types$
+		$.|allLowercasePlural$: newWatchers(),
+$- end$
+	}
+}
+`
+
+var sqlSchemaTmpl = `
+// Schema are the statements which create the tables of the types, the
+// namespace and the name are the primary key and the members which are not
+// numbers, strings or booleans are stored as JSON text.
+var Schema = []string{
+$- range .tables$
+	` + "`" + `$.DDL$` + "`" + `,
+$- end$
+}
+
+// CreateTables creates the tables of the types which do not exist.
+func CreateTables(ctx context.Context, db *sql.DB) error {
+	for _, ddl := range Schema {
+		if _, err := db.ExecContext(ctx, ddl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rowScanner is a *sql.Row or *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+`
+
+var sqlStmtFunc = `
+// stmt returns the prepared statement of the query, the statements are
+// prepared on first use so the tables can be created after New.
+func (s *service) stmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stmt, ok := s.stmts[query]; ok {
+		return stmt, nil
+	}
+	stmt, err := s.opt.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	s.stmts[query] = stmt
+	return stmt, nil
+}
+`
+
+var sqlContinueFuncs = `
+// listLimit returns the limit of a list query, one more than the limit of the
+// options to find out if there are more objects.
+func listLimit(opts ListOptions) int64 {
+	if opts.Limit <= 0 || opts.Limit == math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return opts.Limit + 1
+}
+
+// continueToken returns the continue token of the page starting at name.
+func continueToken(name string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(name))
+}
+
+// continueName returns the name of the first object of the page of the continue token.
+func continueName(token string) (string, error) {
+	name, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", &errors.StatusError{Reason: errors.ReasonInvalid, Message: fmt.Sprintf("invalid continue token %q", token)}
+	}
+	return string(name), nil
+}
+`
+
+var sqlTableTmpl = `
+// the queries of the $.table.Name$ table
+const (
+	insert$.type|public$Query = ` + "`$.table.Insert$`" + `
+	select$.type|public$Query = ` + "`$.table.Select$`" + `
+	list$.type|public$Query   = ` + "`$.table.List$`" + `
+	update$.type|public$Query = ` + "`$.table.Update$`" + `
+$- if .table.HasStatus$
+	update$.type|public$StatusQuery = ` + "`$.table.UpdateStatus$`" + `
+$- end$
+	delete$.type|public$Query = ` + "`$.table.Delete$`" + `
+)
+
+// scan$.type|public$ returns the $.type|private$ of the row of the $.table.Name$ table.
+func scan$.type|public$(row rowScanner) (*$.type|raw$, error) {
+	obj := &$.type|raw${}
+$- range .table.Columns$
+$- if .JSON$
+	var $.Var$ []byte
+$- end$
+$- end$
+	err := row.Scan(&obj.Name$range .table.Columns$, $if .JSON$&$.Var$$else$&obj.$.Member$$end$$end$)
+	if err != nil {
+		return nil, err
+	}
+$- range .table.Columns$
+$- if .JSON$
+	if err := json.Unmarshal($.Var$, &obj.$.Member$); err != nil {
+		return nil, err
+	}
+$- end$
+$- end$
+	return obj, nil
+}
+
+// $.type|private$Values returns the values of the columns of the spec of the $.type|private$
+// and the value of the column of its status, which is nil without a status
+// column. The members which are not numbers, strings or booleans are encoded
+// as JSON.
+func $.type|private$Values(obj *$.type|raw$) ([]interface{}, interface{}, error) {
+	var values []interface{}
+$- range .table.Spec$
+$- if .JSON$
+	$.Var$, err := json.Marshal(obj.$.Member$)
+	if err != nil {
+		return nil, nil, err
+	}
+	values = append(values, string($.Var$))
+$- else$
+	values = append(values, obj.$.Member$)
+$- end$
+$- end$
+$- with .table.Status$
+$- if .JSON$
+	$.Var$, err := json.Marshal(obj.$.Member$)
+	if err != nil {
+		return nil, nil, err
+	}
+	return values, string($.Var$), nil
+$- else$
+	return values, obj.$.Member$, nil
+$- end$
+$- else$
+	return values, nil, nil
+$- end$
+}
+
+// select$.type|public$ returns the $.type|private$ stored under name.
+func (s *service) select$.type|public$(ctx context.Context, $if .table.Namespaced$namespace, $end$name string) (*$.type|raw$, error) {
+	stmt, err := s.stmt(ctx, select$.type|public$Query)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := scan$.type|public$(stmt.QueryRowContext(ctx, $if .table.Namespaced$namespace, $end$name))
+	if err == sql.ErrNoRows {
+		return nil, errors.NewNotFound("$.type|kind$", name)
+	}
+	return obj, err
+}
+`
@@ -0,0 +1,297 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// genSQLService generates the service of a type which stores the objects in
+// the table of the type with the queries of sql.go.
+type genSQLService struct {
+	generator.DefaultGen
+	outputPackage    string
+	imports          namer.ImportTracker
+	serviceGenerated bool
+	typeToGenerate   *types.Type
+}
+
+var _ generator.Generator = &genSQLService{}
+
+// FileType makes the service of the type a scaffold, the user implements it.
+func (g *genSQLService) FileType() string { return util.ScaffoldFileType }
+
+func (g *genSQLService) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+// We only want to call GenerateType() once.
+func (g *genSQLService) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.serviceGenerated
+	g.serviceGenerated = true
+	return ret
+}
+
+func (g *genSQLService) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, "context")
+	imports = append(imports, "fmt")
+	imports = append(imports, filepath.Join(g.outputPackage, "server/errors"))
+	return
+}
+
+func (g *genSQLService) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	klog.Infof("processing type %v", g.typeToGenerate)
+	tags, err := tags.ParseClientGenTags(append(g.typeToGenerate.SecondClosestCommentLines, g.typeToGenerate.CommentLines...))
+	if err != nil {
+		return err
+	}
+
+	hasStatus := util.HasStatus(g.typeToGenerate, tags)
+	_, status := sqlColumns(g.typeToGenerate)
+	m := map[string]interface{}{
+		"type":       g.typeToGenerate,
+		"namespaced": !tags.NonNamespaced,
+		// the events of cluster-scoped objects are sent without a namespace
+		"namespace":  `""`,
+		"keepStatus": status != nil && hasStatus && tags.HasVerb("updateStatus"),
+		// the values of the status column are returned apart
+		"status": status != nil,
+	}
+	if !tags.NonNamespaced {
+		m["namespace"] = "namespace"
+	}
+
+	if tags.HasVerb("create") {
+		sw.Do(createSQLService, m)
+	}
+	if tags.HasVerb("get") {
+		sw.Do(getSQLService, m)
+	}
+	if tags.HasVerb("list") {
+		sw.Do(listSQLService, m)
+	}
+	if tags.HasVerb("watch") {
+		sw.Do(watchSQLService, m)
+	}
+	if tags.HasVerb("update") {
+		sw.Do(updateSQLService, m)
+	}
+	if tags.HasVerb("delete") {
+		sw.Do(deleteSQLService, m)
+	}
+	if hasStatus && tags.HasVerb("get") {
+		sw.Do(getStatusSQLService, m)
+	}
+	if hasStatus && tags.HasVerb("updateStatus") {
+		sw.Do(updateStatusSQLService, m)
+	}
+	for _, e := range util.Extensions(c, g.typeToGenerate, tags) {
+		m["ext"] = e
+		sw.Do(extensionObjectService, m)
+	}
+	return sw.Error()
+}
+
+var createSQLService = `
+// Create$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in a sql database.
+func (s *service) Create$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) (*$.type|raw$, error) {
+	if len($.type|private$Obj.Name) == 0 {
+		return nil, errors.NewInvalid("$.type|kind$", $.type|private$Obj.Name, fmt.Errorf("name is required"))
+	}
+	values, $if .status$status$else$_$end$, err := $.type|private$Values($.type|private$Obj)
+	if err != nil {
+		return nil, err
+	}
+$- if .status$
+	values = append(values, status)
+$- end$
+	stmt, err := s.stmt(ctx, insert$.type|public$Query)
+	if err != nil {
+		return nil, err
+	}
+	_, err = stmt.ExecContext(ctx, append([]interface{}{$if .namespaced$namespace, $end$$.type|private$Obj.Name}, values...)...)
+	if err != nil {
+		// the errors of a duplicate primary key depend on the driver
+		if _, getErr := s.select$.type|public$(ctx, $if .namespaced$namespace, $end$$.type|private$Obj.Name); getErr == nil {
+			return nil, errors.NewAlreadyExists("$.type|kind$", $.type|private$Obj.Name)
+		}
+		return nil, err
+	}
+
+	created, err := s.select$.type|public$(ctx, $if .namespaced$namespace, $end$$.type|private$Obj.Name)
+	if err != nil {
+		return nil, err
+	}
+	s.$.type|allLowercasePlural$.notify($.namespace$, Added, created)
+	return created, nil
+}
+`
+
+var getSQLService = `
+// Get$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in a sql database.
+func (s *service) Get$.type|public$(ctx context.Context, $if .namespaced$namespace, $end$name string) (*$.type|raw$, error) {
+	return s.select$.type|public$(ctx, $if .namespaced$namespace, $end$name)
+}
+`
+
+var listSQLService = `
+// List$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in a sql database.
+func (s *service) List$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$opts ListOptions) (*$.type|public$List, error) {
+	start, err := continueName(opts.Continue)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := s.stmt(ctx, list$.type|public$Query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := stmt.QueryContext(ctx, $if .namespaced$namespace, $end$start, listLimit(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := &$.type|public$List{Items: []$.type|raw${}}
+	for rows.Next() {
+		obj, err := scan$.type|public$(rows)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Limit > 0 && int64(len(list.Items)) == opts.Limit {
+			// the first object of the next page
+			list.Continue = continueToken(obj.Name)
+			break
+		}
+		list.Items = append(list.Items, *obj)
+	}
+	return list, rows.Err()
+}
+`
+
+var watchSQLService = `
+// Watch$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example sends the events of the writes of this service.
+// The events channel must be closed when ctx is done.
+func (s *service) Watch$.type|public$(ctx context.Context$if .namespaced$, namespace string$end$) (<-chan WatchEvent, error) {
+	return s.$.type|allLowercasePlural$.watch(ctx, $.namespace$), nil
+}
+`
+
+var updateSQLService = `
+// Update$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in a sql database.
+func (s *service) Update$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) error {
+$- if .keepStatus$
+	// the status is only written by Update$.type|public$Status
+$- end$
+	values, $if and .status (not .keepStatus)$status$else$_$end$, err := $.type|private$Values($.type|private$Obj)
+	if err != nil {
+		return err
+	}
+$- if and .status (not .keepStatus)$
+	values = append(values, status)
+$- end$
+	stmt, err := s.stmt(ctx, update$.type|public$Query)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.ExecContext(ctx, append(values, $if .namespaced$namespace, $end$$.type|private$Obj.Name)...)
+	if err != nil {
+		return err
+	}
+
+	// the affected rows of an update without changes depend on the driver
+	updated, err := s.select$.type|public$(ctx, $if .namespaced$namespace, $end$$.type|private$Obj.Name)
+	if err != nil {
+		return err
+	}
+	s.$.type|allLowercasePlural$.notify($.namespace$, Modified, updated)
+	return nil
+}
+`
+
+var deleteSQLService = `
+// Delete$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in a sql database.
+func (s *service) Delete$.type|public$(ctx context.Context, $if .namespaced$namespace, $end$name string) error {
+	deleted, err := s.select$.type|public$(ctx, $if .namespaced$namespace, $end$name)
+	if err != nil {
+		return err
+	}
+	stmt, err := s.stmt(ctx, delete$.type|public$Query)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.ExecContext(ctx, $if .namespaced$namespace, $end$name)
+	if err != nil {
+		return err
+	}
+	s.$.type|allLowercasePlural$.notify($.namespace$, Deleted, deleted)
+	return nil
+}
+`
+
+var getStatusSQLService = `
+// Get$.type|public$Status xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in a sql database.
+func (s *service) Get$.type|public$Status(ctx context.Context, $if .namespaced$namespace, $end$name string) (*$.type|raw$, error) {
+	return s.select$.type|public$(ctx, $if .namespaced$namespace, $end$name)
+}
+`
+
+var updateStatusSQLService = `
+// Update$.type|public$Status xxx
+// TODO(user): Modify this function to implement your logic.This example stores the objects in a sql database.
+func (s *service) Update$.type|public$Status(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) error {
+	_, status, err := $.type|private$Values($.type|private$Obj)
+	if err != nil {
+		return err
+	}
+	stmt, err := s.stmt(ctx, update$.type|public$StatusQuery)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.ExecContext(ctx, status, $if .namespaced$namespace, $end$$.type|private$Obj.Name)
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.select$.type|public$(ctx, $if .namespaced$namespace, $end$$.type|private$Obj.Name)
+	if err != nil {
+		return err
+	}
+	s.$.type|allLowercasePlural$.notify($.namespace$, Modified, updated)
+	return nil
+}
+`
@@ -0,0 +1,279 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"io"
+	"path/filepath"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// genStore generates the service of the memory backend, which keeps the
+// objects of each type in an in-memory store.
+type genStore struct {
+	generator.DefaultGen
+	outputPackage   string
+	imports         namer.ImportTracker
+	storeGenerated  bool
+	typesToGenerate []*types.Type
+}
+
+var _ generator.Generator = &genStore{}
+
+func (g *genStore) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+// We only want to call GenerateType() once.
+func (g *genStore) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.storeGenerated
+	g.storeGenerated = true
+	return ret
+}
+
+func (g *genStore) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, "encoding/base64")
+	imports = append(imports, "encoding/json")
+	imports = append(imports, "fmt")
+	imports = append(imports, "sort")
+	imports = append(imports, "strings")
+	imports = append(imports, "sync")
+	imports = append(imports, filepath.Join(g.outputPackage, "server/errors"))
+	return
+}
+
+func (g *genStore) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	m := map[string]interface{}{
+		"types": g.typesToGenerate,
+	}
+
+	sw.Do(typeMemoryServiceStruct, m)
+	sw.Do(newMemoryServiceTmpl, m)
+	sw.Do(typeStoreStruct, m)
+	sw.Do(storeCreateFunc, m)
+	sw.Do(storeGetFunc, m)
+	sw.Do(storeListFunc, m)
+	sw.Do(storeUpdateFunc, m)
+	sw.Do(storeDeleteFunc, m)
+	return sw.Error()
+}
+
+var typeMemoryServiceStruct = `
+// service implements the Service interface, the objects of each type are
+// kept in a store in memory.
+type service struct {
+	opt *Options
+$- range .types$
+	$.|allLowercasePlural$ *store
+$- end$
+}
+`
+
+var newMemoryServiceTmpl = `
+// New is create a service object with empty stores.
+func New(opt *Options) Interface {
+	return &service{
+		opt: opt,
+$- range .types$
+		$.|allLowercasePlural$: newStore("$.|kind$", func() interface{} { return &$.|raw${} }),
+$- end$
+	}
+}
+`
+
+var typeStoreStruct = `
+// store is a thread-safe in-memory store of the objects of a kind keyed by the
+// namespace and the name. The objects are stored encoded as JSON, so the
+// callers never share an object with the store.
+type store struct {
+	kind      string
+	newObject func() interface{}
+
+	mu      sync.RWMutex
+	objects map[string][]byte
+	// the events are sent while the store is locked, so they are in the
+	// order of the writes
+	*watchers
+}
+
+// newStore returns an empty store of the objects created by newObject.
+func newStore(kind string, newObject func() interface{}) *store {
+	return &store{
+		kind:      kind,
+		newObject: newObject,
+		objects:   map[string][]byte{},
+		watchers:  newWatchers(),
+	}
+}
+
+// storeKey returns the key of an object, cluster-scoped objects have an empty namespace.
+func storeKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// decode returns a new object decoded from data.
+func (s *store) decode(data []byte) (interface{}, error) {
+	obj := s.newObject()
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+`
+
+var storeCreateFunc = `
+// create stores obj under name and returns the stored object.
+func (s *store) create(namespace, name string, obj interface{}) (interface{}, error) {
+	if len(name) == 0 {
+		return nil, errors.NewInvalid(s.kind, name, fmt.Errorf("name is required"))
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := storeKey(namespace, name)
+	if _, ok := s.objects[key]; ok {
+		return nil, errors.NewAlreadyExists(s.kind, name)
+	}
+	s.objects[key] = data
+	s.notify(namespace, Added, created)
+	return created, nil
+}
+`
+
+var storeGetFunc = `
+// get returns the object stored under name.
+func (s *store) get(namespace, name string) (interface{}, error) {
+	s.mu.RLock()
+	data, ok := s.objects[storeKey(namespace, name)]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.NewNotFound(s.kind, name)
+	}
+	return s.decode(data)
+}
+`
+
+var storeListFunc = `
+// list returns the objects of the namespace ordered by name, or the objects of
+// all namespaces if namespace is empty. The returned continue token is set if
+// there are more objects than opts.Limit.
+func (s *store) list(namespace string, opts ListOptions) ([]interface{}, string, error) {
+	start := ""
+	if len(opts.Continue) != 0 {
+		token, err := base64.RawURLEncoding.DecodeString(opts.Continue)
+		if err != nil {
+			return nil, "", &errors.StatusError{Reason: errors.ReasonInvalid, Message: fmt.Sprintf("invalid continue token %q", opts.Continue)}
+		}
+		start = string(token)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var keys []string
+	for key := range s.objects {
+		if len(namespace) != 0 && !strings.HasPrefix(key, storeKey(namespace, "")) {
+			continue
+		}
+		if key < start {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var next string
+	if opts.Limit > 0 && int64(len(keys)) > opts.Limit {
+		next = base64.RawURLEncoding.EncodeToString([]byte(keys[opts.Limit]))
+		keys = keys[:opts.Limit]
+	}
+	objects := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		obj, err := s.decode(s.objects[key])
+		if err != nil {
+			return nil, "", err
+		}
+		objects = append(objects, obj)
+	}
+	return objects, next, nil
+}
+`
+
+var storeUpdateFunc = `
+// update replaces the object stored under name with the object returned by
+// updateFunc, which is called with a copy of the stored object while the store
+// is locked.
+func (s *store) update(namespace, name string, updateFunc func(current interface{}) interface{}) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := storeKey(namespace, name)
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, errors.NewNotFound(s.kind, name)
+	}
+	current, err := s.decode(data)
+	if err != nil {
+		return nil, err
+	}
+	data, err = json.Marshal(updateFunc(current))
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.decode(data)
+	if err != nil {
+		return nil, err
+	}
+	s.objects[key] = data
+	s.notify(namespace, Modified, updated)
+	return updated, nil
+}
+`
+
+var storeDeleteFunc = `
+// delete removes the object stored under name.
+func (s *store) delete(namespace, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := storeKey(namespace, name)
+	data, ok := s.objects[key]
+	if !ok {
+		return errors.NewNotFound(s.kind, name)
+	}
+	deleted, err := s.decode(data)
+	if err != nil {
+		return err
+	}
+	delete(s.objects, key)
+	s.notify(namespace, Deleted, deleted)
+	return nil
+}
+`
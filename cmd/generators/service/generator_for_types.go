@@ -19,17 +19,20 @@ package service
 import (
 	"io"
 
+	"github.com/gosoon/code-generator/cmd/generators/util"
+	"github.com/gosoon/code-generator/pkg/tags"
+
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/namer"
 	"k8s.io/gengo/types"
 	"k8s.io/klog"
 )
 
-// genTypesService generates a package for a controller.
+// genTypesService generates the service of a type which stores the objects as
+// the core kind of the kubernetes api with the same name.
 type genTypesService struct {
 	generator.DefaultGen
 	clientsetPackage string
-	inputPackages    []string
 	outputPackage    string
 	imports          namer.ImportTracker
 	serviceGenerated bool
@@ -38,6 +41,9 @@ type genTypesService struct {
 
 var _ generator.Generator = &genTypesService{}
 
+// FileType makes the service of the type a scaffold, the user implements it.
+func (g *genTypesService) FileType() string { return util.ScaffoldFileType }
+
 func (g *genTypesService) Namers(c *generator.Context) namer.NameSystems {
 	return namer.NameSystems{
 		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
@@ -56,10 +62,6 @@ func (g *genTypesService) Imports(c *generator.Context) (imports []string) {
 	imports = append(imports, "k8s.io/klog")
 	imports = append(imports, "apiv1 \"k8s.io/api/core/v1\"")
 	imports = append(imports, "metav1 \"k8s.io/apimachinery/pkg/apis/meta/v1\"")
-
-	for _, pkg := range g.inputPackages {
-		imports = append(imports, pkg)
-	}
 	return
 }
 
@@ -67,73 +69,189 @@ func (g *genTypesService) GenerateType(c *generator.Context, t *types.Type, w io
 	sw := generator.NewSnippetWriter(w, c, "$", "$")
 
 	klog.Infof("processing type %v", g.typeToGenerate)
+	tags, err := tags.ParseClientGenTags(append(g.typeToGenerate.SecondClosestCommentLines, g.typeToGenerate.CommentLines...))
+	if err != nil {
+		return err
+	}
+
 	m := map[string]interface{}{
-		"type": g.typeToGenerate,
+		"type":       g.typeToGenerate,
+		"namespaced": !tags.NonNamespaced,
 	}
 
-	sw.Do(createObjectService, m)
-	sw.Do(getObjectService, m)
-	sw.Do(updateObjectService, m)
-	sw.Do(deleteObjectService, m)
+	sw.Do(kubeConversions, m)
+	if tags.HasVerb("create") {
+		sw.Do(createObjectService, m)
+	}
+	if tags.HasVerb("get") {
+		sw.Do(getObjectService, m)
+	}
+	if tags.HasVerb("list") {
+		sw.Do(listObjectService, m)
+	}
+	if tags.HasVerb("watch") {
+		sw.Do(watchObjectService, m)
+	}
+	if tags.HasVerb("update") {
+		sw.Do(updateObjectService, m)
+	}
+	if tags.HasVerb("delete") {
+		sw.Do(deleteObjectService, m)
+	}
+	hasStatus := util.HasStatus(g.typeToGenerate, tags)
+	if hasStatus && tags.HasVerb("get") {
+		sw.Do(getStatusObjectService, m)
+	}
+	if hasStatus && tags.HasVerb("updateStatus") {
+		sw.Do(updateStatusObjectService, m)
+	}
+	for _, e := range util.Extensions(c, g.typeToGenerate, tags) {
+		m["ext"] = e
+		sw.Do(extensionObjectService, m)
+	}
 	return sw.Error()
 }
 
-var createObjectService = `
-// Create$.type|public$ xxx
-// TODO(user): Modify this function to implement your logic.This example use namespace.
-func (s *service) Create$.type|public$(ctx context.Context, $.type|private$Obj *types.$.type|public$) error {
-    clientset := s.opt.KubeClientset
-    $.type|private$ := &apiv1.$.type|public${
+var kubeConversions = `
+// toKube$.type|public$ converts the $.type|private$ to the $.type|kind$ of the kubernetes api.
+// TODO(user): Modify this function to convert the fields of your type.
+func toKube$.type|public$($if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) *apiv1.$.type|kind$ {
+    return &apiv1.$.type|kind${
         TypeMeta: metav1.TypeMeta{
             APIVersion: "v1",
-            Kind:       "$.type|public$",
+            Kind:       "$.type|kind$",
         },
         ObjectMeta: metav1.ObjectMeta{
             Name: $.type|private$Obj.Name,
+$- if .namespaced$
+            Namespace: namespace,
+$- end$
         },
     }
+}
+
+// fromKube$.type|public$ converts the $.type|kind$ of the kubernetes api to the $.type|private$.
+// TODO(user): Modify this function to convert the fields of your type.
+func fromKube$.type|public$($.type|private$ *apiv1.$.type|kind$) *$.type|raw$ {
+    return &$.type|raw${Name: $.type|private$.Name}
+}
+`
+
+var createObjectService = `
+// Create$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example use namespace.
+func (s *service) Create$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) (*$.type|raw$, error) {
+    clientset := s.opt.KubeClientset
+    $.type|private$ := toKube$.type|public$($if .namespaced$namespace, $end$$.type|private$Obj)
 
-    _, err := clientset.CoreV1().$.type|publicPlural$().Create(namespace)
+    created, err := clientset.CoreV1().$.type|kindPlural$($if .namespaced$namespace$end$).Create($.type|private$)
     if err != nil {
         klog.Errorf("create $.type|private$ failed with:%v", err)
-        return err
-    } 
-    return nil
+        return nil, kubeError(err)
+    }
+    return fromKube$.type|public$(created), nil
 }
 `
 var getObjectService = `
 // Get$.type|public$ xxx
 // TODO(user): Modify this function to implement your logic.This example use namespace.
-func (s *service) Get$.type|public$(ctx context.Context, name string) (*apiv1.$.type|public$, error) {
+func (s *service) Get$.type|public$(ctx context.Context, $if .namespaced$namespace, $end$name string) (*$.type|raw$, error) {
     clientset := s.opt.KubeClientset
 
-    $.type|private$, err := clientset.CoreV1().$.type|publicPlural$().Get(name, metav1.GetOptions{})
+    $.type|private$, err := clientset.CoreV1().$.type|kindPlural$($if .namespaced$namespace$end$).Get(name, metav1.GetOptions{})
     if err != nil {
         klog.Errorf("get $.type|private$ %v failed with:%v", name, err)
-        return nil, err
+        return nil, kubeError(err)
+    }
+
+    return fromKube$.type|public$($.type|private$), nil
+}
+`
+
+var listObjectService = `
+// List$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example use namespace.
+func (s *service) List$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$opts ListOptions) (*$.type|public$List, error) {
+    clientset := s.opt.KubeClientset
+
+    $.type|private$List, err := clientset.CoreV1().$.type|kindPlural$($if .namespaced$namespace$end$).List(metav1.ListOptions{
+        Limit:    opts.Limit,
+        Continue: opts.Continue,
+    })
+    if err != nil {
+        klog.Errorf("list $.type|allLowercasePlural$ failed with:%v", err)
+        return nil, kubeError(err)
     }
 
-    return $.type|private$, nil
+    list := &$.type|public$List{Items: make([]$.type|raw$, 0, len($.type|private$List.Items)), Continue: $.type|private$List.Continue}
+    for i := range $.type|private$List.Items {
+        list.Items = append(list.Items, *fromKube$.type|public$(&$.type|private$List.Items[i]))
+    }
+    return list, nil
+}
+`
+
+var watchObjectService = `
+// Watch$.type|public$ xxx
+// TODO(user): Modify this function to implement your logic.This example use namespace.
+// The events channel must be closed when ctx is done.
+func (s *service) Watch$.type|public$(ctx context.Context$if .namespaced$, namespace string$end$) (<-chan WatchEvent, error) {
+    clientset := s.opt.KubeClientset
+
+    watcher, err := clientset.CoreV1().$.type|kindPlural$($if .namespaced$namespace$end$).Watch(metav1.ListOptions{})
+    if err != nil {
+        klog.Errorf("watch $.type|allLowercasePlural$ failed with:%v", err)
+        return nil, kubeError(err)
+    }
+
+    events := make(chan WatchEvent)
+    go func() {
+        defer close(events)
+        defer watcher.Stop()
+        for {
+            select {
+            case <-ctx.Done():
+                return
+            case event, ok := <-watcher.ResultChan():
+                if !ok {
+                    return
+                }
+                // the errors of the watch are not objects of the kind
+                obj, ok := event.Object.(*apiv1.$.type|kind$)
+                if !ok {
+                    continue
+                }
+                select {
+                case events <- WatchEvent{Type: EventType(event.Type), Object: fromKube$.type|public$(obj)}:
+                case <-ctx.Done():
+                    return
+                }
+            }
+        }
+    }()
+    return events, nil
 }
 `
 
 var updateObjectService = `
 // Update$.type|public$ xxx
 // TODO(user): Modify this function to implement your logic.This example use namespace. 
-func (s *service) Update$.type|public$(ctx context.Context, $.type|private$Obj *types.$.type|public$) error {
+func (s *service) Update$.type|public$(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) error {
     clientset := s.opt.KubeClientset
 
-	var err error
-	$.type|private$, err := clientset.CoreV1().$.type|publicPlural$().Get($.type|private$Obj.Name, metav1.GetOptions{})
+    current, err := clientset.CoreV1().$.type|kindPlural$($if .namespaced$namespace$end$).Get($.type|private$Obj.Name, metav1.GetOptions{})
     if err != nil {
         klog.Errorf("get $.type|private$ %v failed with:%v", $.type|private$Obj.Name, err)
-        return err
+        return kubeError(err)
     }
 
-    $.type|private$, err = clientset.CoreV1().$.type|publicPlural$().Update($.type|private$)
+    $.type|private$ := toKube$.type|public$($if .namespaced$namespace, $end$$.type|private$Obj)
+    // the update replaces the current version of the object
+    $.type|private$.ResourceVersion = current.ResourceVersion
+    _, err = clientset.CoreV1().$.type|kindPlural$($if .namespaced$namespace$end$).Update($.type|private$)
     if err != nil {
         klog.Errorf("update $.type|private$ failed with:%v", err)
-        return err
+        return kubeError(err)
     }
     return nil
 }
@@ -142,20 +260,67 @@ func (s *service) Update$.type|public$(ctx context.Context, $.type|private$Obj *
 var deleteObjectService = `
 // Delete$.type|public$ xxx
 // TODO(user): Modify this function to implement your logic.This example use namespace.
-func (s *service) Delete$.type|public$(ctx context.Context, name string) error {
+func (s *service) Delete$.type|public$(ctx context.Context, $if .namespaced$namespace, $end$name string) error {
     clientset := s.opt.KubeClientset
 
-    _, err := clientset.CoreV1().$.type|publicPlural$().Get(name, metav1.GetOptions{})
+    _, err := clientset.CoreV1().$.type|kindPlural$($if .namespaced$namespace$end$).Get(name, metav1.GetOptions{})
     if err != nil {
         klog.Errorf("get $.type|private$ %v failed with:%v", name, err)
-        return err
+        return kubeError(err)
     }
 
-    err = clientset.CoreV1().$.type|publicPlural$().Delete(name, &metav1.DeleteOptions{})
+    err = clientset.CoreV1().$.type|kindPlural$($if .namespaced$namespace$end$).Delete(name, &metav1.DeleteOptions{})
     if err != nil {
         klog.Errorf("delete $.type|private$Obj %v failed with:%v", name, err)
-        return err
+        return kubeError(err)
     }
     return nil
 }
 `
+
+var getStatusObjectService = `
+// Get$.type|public$Status xxx
+// TODO(user): Modify this function to implement your logic.This example use namespace.
+func (s *service) Get$.type|public$Status(ctx context.Context, $if .namespaced$namespace, $end$name string) (*$.type|raw$, error) {
+    clientset := s.opt.KubeClientset
+
+    $.type|private$, err := clientset.CoreV1().$.type|kindPlural$($if .namespaced$namespace$end$).Get(name, metav1.GetOptions{})
+    if err != nil {
+        klog.Errorf("get $.type|private$ %v status failed with:%v", name, err)
+        return nil, kubeError(err)
+    }
+
+    return fromKube$.type|public$($.type|private$), nil
+}
+`
+
+var updateStatusObjectService = `
+// Update$.type|public$Status xxx
+// TODO(user): Modify this function to implement your logic.This example use namespace.
+func (s *service) Update$.type|public$Status(ctx context.Context, $if .namespaced$namespace string, $end$$.type|private$Obj *$.type|raw$) error {
+    clientset := s.opt.KubeClientset
+
+    $.type|private$, err := clientset.CoreV1().$.type|kindPlural$($if .namespaced$namespace$end$).Get($.type|private$Obj.Name, metav1.GetOptions{})
+    if err != nil {
+        klog.Errorf("get $.type|private$ %v failed with:%v", $.type|private$Obj.Name, err)
+        return kubeError(err)
+    }
+
+    // only the status of the current object is updated
+    $.type|private$.Status = toKube$.type|public$($if .namespaced$namespace, $end$$.type|private$Obj).Status
+    _, err = clientset.CoreV1().$.type|kindPlural$($if .namespaced$namespace$end$).UpdateStatus($.type|private$)
+    if err != nil {
+        klog.Errorf("update $.type|private$ status failed with:%v", err)
+        return kubeError(err)
+    }
+    return nil
+}
+`
+
+var extensionObjectService = `
+// $.ext.Method$ xxx
+// TODO(user): Modify this function to implement your logic.
+func (s *service) $.ext.Method$(ctx context.Context, $if .namespaced$namespace, $end$name string$if or (.ext.HasVerb "create") (.ext.HasVerb "update")$, input *$.ext.Input$$else if .ext.HasVerb "patch"$, data []byte$end$) (*$.ext.Result$, error) {
+    return nil, fmt.Errorf("$.ext.Method$ is not implemented")
+}
+`
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"io"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// genWatchers generates the broadcaster of the watch events of the backends
+// which send the events of their own writes.
+type genWatchers struct {
+	generator.DefaultGen
+	outputPackage    string
+	imports          namer.ImportTracker
+	watcherGenerated bool
+}
+
+var _ generator.Generator = &genWatchers{}
+
+func (g *genWatchers) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+// We only want to call GenerateType() once.
+func (g *genWatchers) Filter(c *generator.Context, t *types.Type) bool {
+	ret := !g.watcherGenerated
+	g.watcherGenerated = true
+	return ret
+}
+
+func (g *genWatchers) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	imports = append(imports, "context")
+	imports = append(imports, "sync")
+	return
+}
+
+func (g *genWatchers) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	m := map[string]interface{}{}
+
+	sw.Do(typeWatchersStruct, m)
+	sw.Do(watchersWatchFunc, m)
+	sw.Do(watchersNotifyFunc, m)
+	return sw.Error()
+}
+
+var typeWatchersStruct = `
+// watchBufferSize is the number of events buffered for a watcher, a watcher
+// which falls behind is closed.
+const watchBufferSize = 100
+
+// watchers broadcasts the events of the objects of a kind to the watchers of
+// their namespace.
+type watchers struct {
+	mu       sync.Mutex
+	watchers map[chan WatchEvent]string
+}
+
+// newWatchers returns a broadcaster without watchers.
+func newWatchers() *watchers {
+	return &watchers{watchers: map[chan WatchEvent]string{}}
+}
+`
+
+var watchersWatchFunc = `
+// watch returns the events of the objects of the namespace, or of all
+// namespaces if namespace is empty, the events channel is closed when ctx is done.
+func (w *watchers) watch(ctx context.Context, namespace string) <-chan WatchEvent {
+	events := make(chan WatchEvent, watchBufferSize)
+	w.mu.Lock()
+	w.watchers[events] = namespace
+	w.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		// the watcher may have been closed because it fell behind
+		if _, ok := w.watchers[events]; ok {
+			delete(w.watchers, events)
+			close(events)
+		}
+	}()
+	return events
+}
+`
+
+var watchersNotifyFunc = `
+// notify sends the event of obj to the watchers of the namespace.
+func (w *watchers) notify(namespace string, eventType EventType, obj interface{}) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for watcher, ns := range w.watchers {
+		if len(ns) != 0 && ns != namespace {
+			continue
+		}
+		select {
+		case watcher <- WatchEvent{Type: eventType, Object: obj}:
+		default:
+			// the watcher is too slow, close it so the client watches again
+			delete(w.watchers, watcher)
+			close(watcher)
+		}
+	}
+}
+`
@@ -17,15 +17,15 @@
 package service
 
 import (
-	"strings"
-
+	"github.com/gosoon/code-generator/cmd/generators/util"
 	"github.com/gosoon/code-generator/pkg/args"
+
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/types"
 )
 
 // PackageForService xxx
-func PackageForService(packageName string, arguments *args.GeneratorArgs, types []*types.Type, boilerplate []byte) generator.Package {
+func PackageForService(packageName string, arguments *args.GeneratorArgs, types []*types.Type, backend util.Backend, boilerplate []byte) generator.Package {
 	return &generator.DefaultPackage{
 		PackageName: "service",
 		PackagePath: packageName,
@@ -44,18 +44,67 @@ func PackageForService(packageName string, arguments *args.GeneratorArgs, types
 						OptionalName: "interface",
 					},
 					typesToGenerate: types,
-					inputPackages:   arguments.InputDirs,
+					outputPackage:   arguments.OutputPackagePath,
 					imports:         generator.NewImportTracker(),
+					backend:         backend,
 				},
 			}
+			// the service struct of the backend
+			switch backend.Name {
+			case "memory":
+				generators = append(generators, &genStore{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: backend.Name,
+					},
+					outputPackage:   arguments.OutputPackagePath,
+					imports:         generator.NewImportTracker(),
+					typesToGenerate: types,
+				})
+			case "sql":
+				generators = append(generators, &genSQL{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: backend.Name,
+					},
+					outputPackage:   arguments.OutputPackagePath,
+					imports:         generator.NewImportTracker(),
+					typesToGenerate: types,
+				})
+			case "kubernetes":
+				generators = append(generators, &genKubernetes{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: backend.Name,
+					},
+					outputPackage: arguments.OutputPackagePath,
+					imports:       generator.NewImportTracker(),
+				})
+			case "crd":
+				generators = append(generators, &genCRD{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: backend.Name,
+					},
+					outputPackage:   arguments.OutputPackagePath,
+					imports:         generator.NewImportTracker(),
+					typesToGenerate: types,
+				})
+			}
+			// the memory and sql backends send the events of their own writes
+			if backend.Name == "memory" || backend.Name == "sql" {
+				generators = append(generators, &genWatchers{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: "watch",
+					},
+					outputPackage: arguments.OutputPackagePath,
+					imports:       generator.NewImportTracker(),
+				})
+			}
 			return generators
 		},
 	}
 }
 
-// PackageForTypes xxx
-func PackageForTypes(packagePath string, arguments *args.GeneratorArgs, t *types.Type, boilerplate []byte) generator.Package {
-	packageName := strings.ToLower(t.Name.Name)
+// PackageForTypes generates the service of the type t in the file named
+// after name, the lowercase name of the type.
+func PackageForTypes(packagePath string, arguments *args.GeneratorArgs, t *types.Type, name string, backend util.Backend, boilerplate []byte) generator.Package {
 	return &generator.DefaultPackage{
 		PackageName: "service",
 		PackagePath: packagePath,
@@ -68,15 +117,51 @@ func PackageForTypes(packagePath string, arguments *args.GeneratorArgs, t *types
 		GeneratorFunc: func(c *generator.Context) (generators []generator.Generator) {
 			generators = []generator.Generator{
 				generator.DefaultGen{OptionalName: "doc"},
-
-				&genTypesService{
+			}
+			switch backend.Name {
+			case "memory":
+				generators = append(generators, &genMemoryService{
 					DefaultGen: generator.DefaultGen{
-						OptionalName: packageName,
+						OptionalName: name,
 					},
 					typeToGenerate: t,
-					inputPackages:  arguments.InputDirs,
+					outputPackage:  arguments.OutputPackagePath,
 					imports:        generator.NewImportTracker(),
-				},
+				})
+			case "sql":
+				generators = append(generators, &genSQLService{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: name,
+					},
+					typeToGenerate: t,
+					outputPackage:  arguments.OutputPackagePath,
+					imports:        generator.NewImportTracker(),
+				})
+			case "kubernetes":
+				generators = append(generators, &genTypesService{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: name,
+					},
+					typeToGenerate: t,
+					outputPackage:  arguments.OutputPackagePath,
+					imports:        generator.NewImportTracker(),
+				})
+			case "crd":
+				generators = append(generators, &genCRDService{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: name,
+					},
+					typeToGenerate: t,
+					outputPackage:  arguments.OutputPackagePath,
+					imports:        generator.NewImportTracker(),
+				}, &genCRDTest{
+					DefaultGen: generator.DefaultGen{
+						OptionalName: name + "_test",
+					},
+					typeToGenerate: t,
+					outputPackage:  arguments.OutputPackagePath,
+					imports:        generator.NewImportTracker(),
+				})
 			}
 			return generators
 		},
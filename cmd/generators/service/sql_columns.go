@@ -0,0 +1,117 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"k8s.io/gengo/types"
+)
+
+// sqlColumn is a column of the table of a type, the namespace and the name of
+// the objects are the primary key and not listed as columns.
+type sqlColumn struct {
+	// Name is the snake case name of the member.
+	Name string
+	// Member is the name of the member of the type.
+	Member string
+	// Type is the sql type of the column.
+	Type string
+	// JSON is true for the members which are stored encoded as JSON.
+	JSON bool
+}
+
+// Var returns the name of the variable of the JSON encoding of the member.
+func (c sqlColumn) Var() string {
+	return strings.ToLower(c.Member[:1]) + c.Member[1:] + "JSON"
+}
+
+// sqlTypes are the sql types of the builtin types which are stored in a
+// column of their own, all other members are stored as JSON text.
+var sqlTypes = map[string]string{
+	"string":  "TEXT",
+	"bool":    "BOOLEAN",
+	"int":     "BIGINT",
+	"int8":    "INTEGER",
+	"int16":   "INTEGER",
+	"int32":   "INTEGER",
+	"int64":   "BIGINT",
+	"uint":    "BIGINT",
+	"uint8":   "INTEGER",
+	"uint16":  "INTEGER",
+	"uint32":  "BIGINT",
+	"uint64":  "BIGINT",
+	"float32": "DOUBLE PRECISION",
+	"float64": "DOUBLE PRECISION",
+}
+
+// sqlColumns returns the columns of the exported members of t, the column of
+// the "Status" member is returned apart so it can be left out of the updates
+// of the spec.
+func sqlColumns(t *types.Type) (spec []sqlColumn, status *sqlColumn) {
+	for _, m := range t.Members {
+		if !m.Embedded && strings.ToLower(m.Name[:1]) == m.Name[:1] {
+			continue
+		}
+		if reflect.StructTag(m.Tags).Get("json") == "-" {
+			continue
+		}
+		// the name is a part of the primary key
+		if m.Name == "Name" && !m.Embedded {
+			continue
+		}
+		column := sqlColumn{Name: snakeCase(m.Name), Member: m.Name, Type: "TEXT", JSON: true}
+		if m.Type.Kind == types.Alias {
+			m.Type = m.Type.Underlying
+		}
+		if sqlType, ok := sqlTypes[m.Type.Name.Name]; ok && m.Type.Kind == types.Builtin && !m.Embedded {
+			column.Type, column.JSON = sqlType, false
+		}
+		if m.Name == "Status" && !m.Embedded {
+			status = &column
+			continue
+		}
+		spec = append(spec, column)
+	}
+	return spec, status
+}
+
+// quoteIdent returns the identifier quoted with the double quotes of standard
+// sql, so the columns can be named after reserved words, e.g. "order".
+func quoteIdent(name string) string {
+	return `"` + name + `"`
+}
+
+// snakeCase returns the lower case name with underscores between the words,
+// e.g. "ClusterIP" is "cluster_ip".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"example.com/e2e/out/memory/server"
+	"example.com/e2e/out/memory/server/controller"
+	v1 "example.com/e2e/types/v1"
+)
+
+func TestClient(t *testing.T) {
+	ts := httptest.NewServer(server.New(server.Options{CtrlOptions: &controller.Options{}}))
+	defer ts.Close()
+	ctx := context.Background()
+
+	c, err := New(Config{Host: ts.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	widgetObj := &v1.Widget{}
+	widgetObj.Name = "test"
+	if _, err := c.Widgets("default").Create(ctx, widgetObj); err != nil {
+		t.Fatalf("Create failed with:%v", err)
+	}
+	list, err := c.Widgets("default").List(ctx, ListOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("List failed with:%v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "test" {
+		t.Errorf("unexpected list %+v", list)
+	}
+	widget, err := c.Widgets("default").Get(ctx, "missing")
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if widget != nil {
+		t.Errorf("expected no widget with the error, got %+v", widget)
+	}
+
+	// the router replies the unknown paths with a plain text 404
+	c, err = New(Config{Host: ts.URL + "/unknown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Widgets("default").Get(ctx, "test")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if message := err.(*StatusError).Message; message != "404 page not found" {
+		t.Errorf("expected the message of the router, got %q", message)
+	}
+}
@@ -0,0 +1,45 @@
+package widget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"example.com/e2e/out/memory/server/controller"
+	"example.com/e2e/out/memory/server/errors"
+	"example.com/e2e/out/memory/server/service"
+	v1 "example.com/e2e/types/v1"
+)
+
+// scaleService replies the scale extension with err.
+type scaleService struct {
+	service.Interface
+	err error
+}
+
+func (s *scaleService) UpdateScaleWidget(ctx context.Context, namespace, name string, input *v1.Scale) (*v1.Scale, error) {
+	return nil, s.err
+}
+
+func TestExtensionError(t *testing.T) {
+	cases := map[int]error{
+		http.StatusNotFound:            errors.NewNotFound("widget", "test"),
+		http.StatusInternalServerError: fmt.Errorf("UpdateScaleWidget is not implemented"),
+	}
+	for code, err := range cases {
+		router := mux.NewRouter()
+		New(&controller.Options{Service: &scaleService{err: err}}).Register(router)
+
+		r := httptest.NewRequest("PUT", "/apis/example.com/v1/namespaces/default/widget/test/scale", strings.NewReader(`{"replicas":2}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		if w.Code != code {
+			t.Errorf("expected the status code %d for %v, got %d", code, err, w.Code)
+		}
+	}
+}
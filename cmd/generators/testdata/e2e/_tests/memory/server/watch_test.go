@@ -0,0 +1,50 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/e2e/out/memory/server/controller"
+)
+
+func TestWatch(t *testing.T) {
+	ts := httptest.NewServer(New(Options{CtrlOptions: &controller.Options{}}))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/apis/example.com/v1/namespaces/default/widgets?watch=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("unexpected response %d %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	// the headers are flushed before the first event
+	created, err := http.Post(ts.URL+"/apis/example.com/v1/namespaces/default/widget", "application/json", strings.NewReader(`{"name":"test"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	created.Body.Close()
+
+	line, err := bufio.NewReader(resp.Body).ReadBytes('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event struct {
+		Type   string `json:"type"`
+		Object struct {
+			Name string `json:"name"`
+		} `json:"object"`
+	}
+	if err := json.Unmarshal(line, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != "ADDED" || event.Object.Name != "test" {
+		t.Errorf("unexpected event %s", line)
+	}
+}
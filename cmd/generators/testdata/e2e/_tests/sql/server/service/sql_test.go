@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"example.com/e2e/out/sql/server/errors"
+	v1 "example.com/e2e/types/v1"
+)
+
+func TestSQLService(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// every connection has its own in-memory database
+	db.SetMaxOpenConns(1)
+	ctx := context.Background()
+	if err := CreateTables(ctx, db); err != nil {
+		t.Fatalf("CreateTables failed with:%v", err)
+	}
+	s := New(&Options{DB: db})
+
+	// the columns are named after the reserved words order and group
+	for _, name := range []string{"a", "b", "c"} {
+		widgetObj := &v1.Widget{Replicas: 1, Order: 2, Group: "g"}
+		widgetObj.Name = name
+		created, err := s.CreateWidget(ctx, "default", widgetObj)
+		if err != nil {
+			t.Fatalf("CreateWidget failed with:%v", err)
+		}
+		if !reflect.DeepEqual(created, widgetObj) {
+			t.Errorf("expected %+v, got %+v", widgetObj, created)
+		}
+	}
+	widgetObj := &v1.Widget{}
+	widgetObj.Name = "a"
+	if _, err := s.CreateWidget(ctx, "default", widgetObj); !errors.IsAlreadyExists(err) {
+		t.Errorf("expected an already exists error, got %v", err)
+	}
+
+	list, err := s.ListWidget(ctx, "default", ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("ListWidget failed with:%v", err)
+	}
+	if len(list.Items) != 2 || list.Items[0].Name != "a" || list.Items[1].Name != "b" || len(list.Continue) == 0 {
+		t.Fatalf("unexpected first page %+v", list)
+	}
+	list, err = s.ListWidget(ctx, "default", ListOptions{Limit: 2, Continue: list.Continue})
+	if err != nil {
+		t.Fatalf("ListWidget failed with:%v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "c" || len(list.Continue) != 0 {
+		t.Errorf("unexpected last page %+v", list)
+	}
+
+	// the update of the spec keeps the status and the update of the status keeps the spec
+	updated := &v1.Widget{Replicas: 3, Order: 4, Group: "h", Status: v1.WidgetStatus{Ready: true}}
+	updated.Name = "a"
+	if err := s.UpdateWidget(ctx, "default", updated); err != nil {
+		t.Fatalf("UpdateWidget failed with:%v", err)
+	}
+	got, err := s.GetWidgetStatus(ctx, "default", "a")
+	if err != nil {
+		t.Fatalf("GetWidgetStatus failed with:%v", err)
+	}
+	if got.Status.Ready || got.Order != 4 {
+		t.Errorf("expected the updated spec and the created status, got %+v", got)
+	}
+	status := &v1.Widget{Status: v1.WidgetStatus{Ready: true}}
+	status.Name = "a"
+	if err := s.UpdateWidgetStatus(ctx, "default", status); err != nil {
+		t.Fatalf("UpdateWidgetStatus failed with:%v", err)
+	}
+	got, err = s.GetWidget(ctx, "default", "a")
+	if err != nil {
+		t.Fatalf("GetWidget failed with:%v", err)
+	}
+	if !reflect.DeepEqual(got, updated) {
+		t.Errorf("expected %+v, got %+v", updated, got)
+	}
+
+	if err := s.DeleteWidget(ctx, "default", "a"); err != nil {
+		t.Fatalf("DeleteWidget failed with:%v", err)
+	}
+	if _, err := s.GetWidget(ctx, "default", "a"); !errors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,5 @@
+// +groupVersion=example.com/v1
+
+// Package collisions has the types whose generated names collide, they are
+// reported as diagnostics.
+package collisions
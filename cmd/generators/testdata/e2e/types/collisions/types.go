@@ -0,0 +1,18 @@
+package collisions
+
+// +genclient
+// +genclient:method=Get,verb=get
+// +genclient:method=ListTool,verb=get
+
+// Gadget has an extension named after its get method and an extension named
+// after the list method of the tool gadgets.
+type Gadget struct {
+	Name string `json:"name"`
+}
+
+// +genclient
+
+// ToolGadget has a list method named like an extension of the gadgets.
+type ToolGadget struct {
+	Name string `json:"name"`
+}
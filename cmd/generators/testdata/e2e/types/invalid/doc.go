@@ -0,0 +1,5 @@
+// +groupVersion=example.com/v1
+
+// Package invalid has the types with invalid tags, they are reported as
+// diagnostics.
+package invalid
@@ -0,0 +1,28 @@
+package invalid
+
+// +genclient
+// +genclient:method=Scale,verb=update,input=Scale,result=Scale
+
+// Sprocket has an extension whose input and result types do not exist and
+// invalid tags on several fields.
+type Sprocket struct {
+	// +validation:minLength=1
+	Replicas int `json:"replicas"`
+	// +default=yes
+	Size int `json:"size"`
+	Spec SprocketSpec `json:"spec"`
+}
+
+// SprocketSpec has an invalid tag on a field.
+type SprocketSpec struct {
+	// +validation:pattern=^a
+	Count int `json:"count"`
+}
+
+// +genclient
+
+// Cog uses the spec of the sprockets, the invalid tag of the spec is
+// reported once.
+type Cog struct {
+	Spec SprocketSpec `json:"spec"`
+}
@@ -0,0 +1,5 @@
+// +groupVersion=apps.a.com/v1
+
+// Package a has a type named like the type of another group whose generated
+// names have the same version prefix, it is reported as a diagnostic.
+package a
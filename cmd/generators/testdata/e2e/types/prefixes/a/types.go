@@ -0,0 +1,8 @@
+package a
+
+// +genclient
+
+// Widget is served by the group apps.a.com.
+type Widget struct {
+	Name string `json:"name"`
+}
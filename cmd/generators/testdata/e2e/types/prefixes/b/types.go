@@ -0,0 +1,8 @@
+package b
+
+// +genclient
+
+// Widget is served by the group apps.b.com.
+type Widget struct {
+	Name string `json:"name"`
+}
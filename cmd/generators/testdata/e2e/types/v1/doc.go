@@ -0,0 +1,5 @@
+// +groupVersion=example.com/v1
+
+// Package v1 has the types of the servers generated by the tests of the
+// generators.
+package v1
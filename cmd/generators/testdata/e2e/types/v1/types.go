@@ -0,0 +1,31 @@
+package v1
+
+// Meta is the metadata of the objects, it is embedded in the types.
+type Meta struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// +genclient
+// +genclient:method=UpdateScale,verb=update,subresource=scale,input=Scale,result=Scale
+
+// Widget is a type with an embedded metadata, a defaulted field, fields named
+// after sql reserved words and an extension.
+type Widget struct {
+	Meta `json:",inline"`
+	// +default=1
+	Replicas int32        `json:"replicas"`
+	Order    int          `json:"order"`
+	Group    string       `json:"group,omitempty"`
+	Status   WidgetStatus `json:"status"`
+}
+
+// WidgetStatus is the status of a widget.
+type WidgetStatus struct {
+	Ready bool `json:"ready"`
+}
+
+// Scale is the input and the result of the scale subresource of a widget.
+type Scale struct {
+	Replicas int32 `json:"replicas"`
+}
@@ -0,0 +1,5 @@
+// +groupVersion=example.com/v2
+
+// Package v2 has the second version of the widgets, the generated names of
+// the types served by both versions are prefixed with their version.
+package v2
@@ -0,0 +1,16 @@
+package v2
+
+// +genclient
+// +genclient:method=UpdateScale,verb=update,subresource=scale,input=Scale,result=Scale
+
+// Widget is the second version of the widgets, with the extension of the
+// first version.
+type Widget struct {
+	Name     string `json:"name"`
+	Replicas int32  `json:"replicas"`
+}
+
+// Scale is the input and the result of the scale subresource of a widget.
+type Scale struct {
+	Replicas int32 `json:"replicas"`
+}
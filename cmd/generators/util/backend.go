@@ -0,0 +1,67 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+// Backend describes the storage of the objects behind the generated services.
+type Backend struct {
+	// Name is the value of the --backend flag.
+	Name string
+	// Options are the fields of the controller and service options which
+	// configure the backend.
+	Options []Option
+}
+
+// Option is a field of the controller and service options.
+type Option struct {
+	Name   string
+	Type   string
+	Import string
+}
+
+// Backends are the supported backends by name.
+var Backends = map[string]Backend{
+	"crd": {
+		Name: "crd",
+		Options: []Option{
+			{Name: "DynamicClient", Type: "dynamic.Interface", Import: "k8s.io/client-go/dynamic"},
+		},
+	},
+	"kubernetes": {
+		Name: "kubernetes",
+		Options: []Option{
+			{Name: "KubeClientset", Type: "kubernetes.Interface", Import: "k8s.io/client-go/kubernetes"},
+		},
+	},
+	"memory": {
+		Name: "memory",
+	},
+	"sql": {
+		Name: "sql",
+		Options: []Option{
+			{Name: "DB", Type: "*sql.DB", Import: "database/sql"},
+		},
+	},
+}
+
+// OptionImports returns the imports of the types of the backend options.
+func (b Backend) OptionImports() []string {
+	var imports []string
+	for _, o := range b.Options {
+		imports = append(imports, o.Import)
+	}
+	return imports
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// CustomResource is the custom resource which stores the objects of a type in
+// the crd backend.
+type CustomResource struct {
+	GroupVersion
+	// Plural is the lowercase plural name of the type, e.g. "namespaces".
+	Plural string
+	// Kind is the name of the type.
+	Kind string
+}
+
+// CustomResourceFor returns the custom resource of the type t in the group
+// version of its package, the errors of the tags of the input packages are
+// reported before the code is generated.
+func CustomResourceFor(c *generator.Context, t *types.Type) CustomResource {
+	gv, _ := PackageGroupVersion(c.Universe.Package(t.Name.Package))
+	return CustomResource{
+		GroupVersion: gv,
+		Plural:       strings.ToLower(c.Namers["kindPlural"].Name(t)),
+		Kind:         c.Namers["kind"].Name(t),
+	}
+}
+
+// MemberJSONName returns the json name of the member of t, it is empty if t
+// has no such member or the member is not encoded. The members of the
+// embedded structs without a json name are promoted like encoding/json does,
+// e.g. the name of an embedded metadata.
+func MemberJSONName(t *types.Type, name string) string {
+	for _, m := range t.Members {
+		if m.Name != name || m.Embedded {
+			continue
+		}
+		name, _ := jsonPath(m)
+		return name
+	}
+	for _, m := range t.Members {
+		if !m.Embedded {
+			continue
+		}
+		elem, s := structOf(m.Type)
+		if promoted, ok := jsonPath(m); !ok || len(promoted) != 0 || (elem != "struct" && elem != "pointer") {
+			continue
+		}
+		if jsonName := MemberJSONName(underlying(s), name); len(jsonName) != 0 {
+			return jsonName
+		}
+	}
+	return ""
+}
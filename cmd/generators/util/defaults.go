@@ -0,0 +1,250 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/types"
+)
+
+// StructDefaults are the default values of the members of a struct type.
+type StructDefaults struct {
+	Type    *types.Type
+	Members []MemberDefault
+}
+
+// MemberDefault is the default value of a member of a struct type.
+type MemberDefault struct {
+	// Name is the name of the member.
+	Name string
+	// Type is the type of the member.
+	Type *types.Type
+	// Kind is the kind of the member with a +default tag, "string",
+	// "number", "pointer", "slice" or "map", it is empty for the members
+	// which only hold a struct with default values.
+	Kind string
+	// Value is the go literal of the default value, e.g. `"a"` or `3`, of
+	// the value a pointer points to, or the elements of the composite
+	// literal of a slice or a map, e.g. `{"a", "b"}`.
+	Value string
+	// Struct is the named struct type of a member which holds a struct with
+	// default values, Elem is how the member holds it, like the Struct and
+	// Elem of a MemberValidation.
+	Struct *types.Type
+	Elem   string
+}
+
+// Defaults returns the default values of the members of t, which is first,
+// and of the named struct types of its members which have default values.
+// The +default tags are checked against the types of the members, the error
+// is the FieldErrors of all the members with invalid tags.
+func Defaults(t *types.Type) ([]StructDefaults, error) {
+	d := &defaults{defaulted: map[types.Name]bool{}}
+	d.add(t)
+	if len(d.errs) != 0 {
+		return nil, d.errs
+	}
+	ret := d.structs[:1]
+	for _, s := range d.structs[1:] {
+		if len(s.Members) != 0 {
+			ret = append(ret, s)
+		}
+	}
+	return ret, nil
+}
+
+type defaults struct {
+	structs []StructDefaults
+	// defaulted is true for the struct types which have default values.
+	defaulted map[types.Name]bool
+	errs      FieldErrors
+}
+
+// add adds the default values of the struct type t and of the struct types
+// of its members, it returns false if no member of t has a default value.
+// The members with invalid tags are added to the errors and skipped.
+func (d *defaults) add(t *types.Type) bool {
+	if defaulted, ok := d.defaulted[t.Name]; ok {
+		return defaulted
+	}
+	// the members of recursive types are defaulted by the function of the type
+	d.defaulted[t.Name] = true
+	index := len(d.structs)
+	d.structs = append(d.structs, StructDefaults{Type: t})
+
+	var members []MemberDefault
+	for _, m := range t.Members {
+		if _, ok := jsonPath(m); !ok {
+			continue
+		}
+		value, ok, err := tags.ParseDefaultTag(m.CommentLines)
+		if err != nil {
+			d.errs = append(d.errs, &FieldError{Type: t.Name, Member: m.Name, Err: err})
+			continue
+		}
+		member := MemberDefault{Name: m.Name, Type: m.Type}
+		if ok {
+			if err := member.setValue(m.Type, value); err != nil {
+				err = fmt.Errorf("invalid +default=%s: %v", value, err)
+				d.errs = append(d.errs, &FieldError{Type: t.Name, Member: m.Name, Err: err})
+				continue
+			}
+			members = append(members, member)
+			continue
+		}
+
+		elem, s := structOf(m.Type)
+		if s == nil || len(s.Name.Name) == 0 {
+			continue
+		}
+		if d.add(s) {
+			member.Struct, member.Elem = s, elem
+			members = append(members, member)
+		}
+	}
+	d.structs[index].Members = members
+	d.defaulted[t.Name] = len(members) != 0
+	return len(members) != 0
+}
+
+// setValue sets the kind of the member of type t and the go literal of the
+// default value.
+func (member *MemberDefault) setValue(t *types.Type, value string) error {
+	t = underlying(t)
+	switch t.Kind {
+	case types.Builtin:
+		if t.Name == types.Bool.Name {
+			// false can not be told apart from a missing value
+			return fmt.Errorf("a bool field can not have a default value, use a *bool field")
+		}
+		literal, err := scalarLiteral(t, value, true)
+		if err != nil {
+			return err
+		}
+		member.Kind, member.Value = "number", literal
+		if t.Name == types.String.Name {
+			member.Kind = "string"
+		}
+	case types.Pointer:
+		literal, err := scalarLiteral(underlying(t.Elem), value, true)
+		if err != nil {
+			return err
+		}
+		member.Kind, member.Value = "pointer", literal
+	case types.Slice:
+		var items []json.RawMessage
+		if err := json.Unmarshal([]byte(value), &items); err != nil {
+			return fmt.Errorf("expected a json array: %v", err)
+		}
+		literals := make([]string, 0, len(items))
+		for _, item := range items {
+			literal, err := scalarLiteral(underlying(t.Elem), string(item), false)
+			if err != nil {
+				return err
+			}
+			literals = append(literals, literal)
+		}
+		member.Kind, member.Value = "slice", "{"+strings.Join(literals, ", ")+"}"
+	case types.Map:
+		if underlying(t.Key).Name != types.String.Name {
+			return fmt.Errorf("only the maps with string keys can have a default value")
+		}
+		var items map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(value), &items); err != nil {
+			return fmt.Errorf("expected a json object: %v", err)
+		}
+		keys := make([]string, 0, len(items))
+		for key := range items {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		literals := make([]string, 0, len(items))
+		for _, key := range keys {
+			literal, err := scalarLiteral(underlying(t.Elem), string(items[key]), false)
+			if err != nil {
+				return err
+			}
+			literals = append(literals, strconv.Quote(key)+": "+literal)
+		}
+		member.Kind, member.Value = "map", "{"+strings.Join(literals, ", ")+"}"
+	default:
+		return fmt.Errorf("%s fields can not have a default value", strings.ToLower(string(t.Kind)))
+	}
+	return nil
+}
+
+// integerBits are the sizes of the integer types.
+var integerBits = map[string]int{
+	"int": 64, "int8": 8, "int16": 16, "int32": 32, "int64": 64,
+	"uint": 64, "uint8": 8, "uint16": 16, "uint32": 32, "uint64": 64,
+	"byte": 8, "rune": 32,
+}
+
+// scalarLiteral returns the go literal of the json value of the builtin type
+// t, the strings do not need to be quoted if unquoted is true.
+func scalarLiteral(t *types.Type, value string, unquoted bool) (string, error) {
+	if t.Kind != types.Builtin {
+		return "", fmt.Errorf("only strings, numbers and bools can be default values, not %s", strings.ToLower(string(t.Kind)))
+	}
+	name := t.Name.Name
+	switch {
+	case t.Name == types.String.Name:
+		if unquoted && !strings.HasPrefix(value, `"`) {
+			return strconv.Quote(value), nil
+		}
+		var s string
+		if err := json.Unmarshal([]byte(value), &s); err != nil {
+			return "", fmt.Errorf("expected a string: %v", err)
+		}
+		return strconv.Quote(s), nil
+	case t.Name == types.Bool.Name:
+		if value != "true" && value != "false" {
+			return "", fmt.Errorf("expected true or false, got %s", value)
+		}
+		return value, nil
+	case strings.HasPrefix(name, "int") || name == "rune":
+		i, err := strconv.ParseInt(value, 10, integerBits[name])
+		if err != nil {
+			return "", fmt.Errorf("expected an integer: %v", err)
+		}
+		return strconv.FormatInt(i, 10), nil
+	case strings.HasPrefix(name, "uint") && name != "uintptr" || name == "byte":
+		u, err := strconv.ParseUint(value, 10, integerBits[name])
+		if err != nil {
+			return "", fmt.Errorf("expected an unsigned integer: %v", err)
+		}
+		return strconv.FormatUint(u, 10), nil
+	case name == "float32" || name == "float64":
+		bits := 64
+		if name == "float32" {
+			bits = 32
+		}
+		f, err := strconv.ParseFloat(value, bits)
+		if err != nil {
+			return "", fmt.Errorf("expected a number: %v", err)
+		}
+		return strconv.FormatFloat(f, 'g', -1, bits), nil
+	}
+	return "", fmt.Errorf("%s fields can not have a default value", name)
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"strings"
+
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// httpMethods maps the extension verb types to the http method of the route.
+var httpMethods = map[string]string{
+	"create": "POST",
+	"get":    "GET",
+	"update": "PUT",
+	"patch":  "PATCH",
+}
+
+// Extension contains the names used by the generated code of a
+// +genclient:method extension.
+type Extension struct {
+	// VerbType is the verb of the extension, e.g. "update".
+	VerbType string
+	// Method is the name of the service method, e.g. "UpdateScaleDeployment".
+	Method string
+	// Handler is the name of the controller handler, e.g. "updateScale".
+	Handler string
+	// HTTPMethod is the method of the route, e.g. "PUT".
+	HTTPMethod string
+	// Path is the route of the extension relative to the object route, it is
+	// the sub-resource or the lowercase verb name, e.g. "/scale".
+	Path string
+	// Input is the go expression of the request body type, e.g. "v1.Scale".
+	Input string
+	// Result is the go expression of the response type, e.g. "v1.Scale".
+	Result string
+	// InputType is the request body type.
+	InputType *types.Type
+	// ResultType is the response type.
+	ResultType *types.Type
+}
+
+// HasVerb checks if the extension matches the given verb.
+func (e Extension) HasVerb(verb string) bool {
+	return e.VerbType == verb
+}
+
+// Extensions returns the extensions of the type t. The input and result
+// types default to t, overrides without a package refer to the package of t.
+func Extensions(c *generator.Context, t *types.Type, tags tags.Tags) []Extension {
+	var ret []Extension
+	for _, e := range tags.Extensions {
+		ext := Extension{
+			VerbType:   e.VerbType,
+			Method:     ExtensionMethod(c, t, e.VerbName),
+			Handler:    strings.ToLower(e.VerbName[:1]) + e.VerbName[1:],
+			HTTPMethod: httpMethods[e.VerbType],
+			Path:       "/" + strings.ToLower(e.VerbName),
+			Input:      typeName(c, t, t.Name.Name, ""),
+			Result:     typeName(c, t, t.Name.Name, ""),
+			InputType:  t,
+			ResultType: t,
+		}
+		if e.IsSubresource() {
+			ext.Path = "/" + e.SubResourcePath
+		}
+		if len(e.InputTypeOverride) > 0 {
+			name, pkg := e.Input()
+			ext.Input = typeName(c, t, name, pkg)
+			ext.InputType = lookupType(c, t, name, pkg)
+		}
+		if len(e.ResultTypeOverride) > 0 {
+			name, pkg := e.Result()
+			ext.Result = typeName(c, t, name, pkg)
+			ext.ResultType = lookupType(c, t, name, pkg)
+		}
+		ret = append(ret, ext)
+	}
+	return ret
+}
+
+// ExtensionMethod returns the name of the service method of the extension
+// verbName of t, it is named after the versioned type, e.g.
+// "UpdateScaleV2Deployment".
+func ExtensionMethod(c *generator.Context, t *types.Type, verbName string) string {
+	return verbName + c.Namers["public"].Name(t)
+}
+
+// typeName returns the go expression of the named type, the raw namer of
+// the generator imports its package.
+func typeName(c *generator.Context, t *types.Type, name, pkg string) string {
+	return c.Namers["raw"].Name(lookupType(c, t, name, pkg))
+}
+
+// lookupType returns the named type from the universe, types without a
+// package are looked up in the package of t.
+func lookupType(c *generator.Context, t *types.Type, name, pkg string) *types.Type {
+	if len(pkg) == 0 {
+		pkg = t.Name.Package
+	}
+	return c.Universe.Type(types.Name{Package: pkg, Name: name})
+}
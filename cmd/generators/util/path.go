@@ -0,0 +1,46 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"strings"
+
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// ResourcePath returns the route of the type relative to the api prefix, the
+// objects are addressed by appending "/{name}". Namespaced types are nested
+// under "/namespaces/{namespace}".
+func ResourcePath(t *types.Type, tags tags.Tags) string {
+	return namespacePath(tags) + "/" + strings.ToLower(t.Name.Name)
+}
+
+// CollectionPath returns the route of the collection of the type relative to
+// the api prefix, it uses the lowercase plural name of the type.
+func CollectionPath(t *types.Type, tags tags.Tags) string {
+	return namespacePath(tags) + "/" + namer.NewAllLowercasePluralNamer(nil).Name(t)
+}
+
+func namespacePath(tags tags.Tags) string {
+	if tags.NonNamespaced {
+		return ""
+	}
+	return "/namespaces/{namespace}"
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import "fmt"
+
+// Router describes the router package which dispatches the requests of the
+// generated server to the controllers.
+type Router struct {
+	// Name is the value of the --router flag.
+	Name string
+	// Import is the import line of the router package, empty for the
+	// standard library.
+	Import string
+	// Type is the type of the router passed to the controllers.
+	Type string
+	// New is the expression which creates the router.
+	New string
+}
+
+// Routers are the supported routers by name.
+var Routers = map[string]Router{
+	"mux": {
+		Name:   "mux",
+		Import: "github.com/gorilla/mux",
+		Type:   "*mux.Router",
+		New:    "mux.NewRouter().StrictSlash(true)",
+	},
+	"servemux": {
+		Name: "servemux",
+		Type: "*http.ServeMux",
+		New:  "http.NewServeMux()",
+	},
+}
+
+// PathValue returns the expression of the value of the path variable key of
+// the request r.
+func (router Router) PathValue(key string) string {
+	if router.Name == "servemux" {
+		return fmt.Sprintf("r.PathValue(%q)", key)
+	}
+	return fmt.Sprintf("mux.Vars(r)[%q]", key)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// Route is a route of the controller of a type, the controllers and the
+// OpenAPI document are generated from the same routes.
+type Route struct {
+	// Verb is the verb served by the route, e.g. "list" or "updateStatus", or
+	// "extension" for the routes of the +genclient:method extensions.
+	Verb    string
+	Comment string
+	Method  string
+	// Path is relative to the api prefix of the type.
+	Path    string
+	Handler string
+	// Extension is the extension of the "extension" routes.
+	Extension *Extension
+}
+
+// Routes returns the routes of the verbs of the type t allowed by the tags,
+// the list route also serves the watch verb.
+func Routes(c *generator.Context, t *types.Type, tags tags.Tags) []Route {
+	public := c.Namers["public"].Name(t)
+	path := ResourcePath(t, tags)
+	var routes []Route
+	if tags.HasVerb("create") {
+		routes = append(routes, Route{Verb: "create", Comment: "create", Method: "POST", Path: path, Handler: "create" + public})
+	}
+	if tags.HasVerb("get") {
+		routes = append(routes, Route{Verb: "get", Comment: "get", Method: "GET", Path: path + "/{name}", Handler: "get" + public})
+	}
+	if tags.HasVerb("list") {
+		routes = append(routes, Route{Verb: "list", Comment: "list", Method: "GET", Path: CollectionPath(t, tags), Handler: "list" + public})
+	} else if tags.HasVerb("watch") {
+		routes = append(routes, Route{Verb: "watch", Comment: "watch", Method: "GET", Path: CollectionPath(t, tags), Handler: "watch" + public})
+	}
+	if tags.HasVerb("update") {
+		routes = append(routes, Route{Verb: "update", Comment: "update", Method: "PUT", Path: path + "/{name}", Handler: "update" + public})
+	}
+	if tags.HasVerb("delete") {
+		routes = append(routes, Route{Verb: "delete", Comment: "delete", Method: "DELETE", Path: path + "/{name}", Handler: "delete" + public})
+	}
+	if HasPatch(tags) {
+		routes = append(routes, Route{Verb: "patch", Comment: "patch", Method: "PATCH", Path: path + "/{name}", Handler: "patch" + public})
+	}
+	hasStatus := HasStatus(t, tags)
+	if hasStatus && tags.HasVerb("get") {
+		routes = append(routes, Route{Verb: "getStatus", Comment: "get status", Method: "GET", Path: path + "/{name}/status", Handler: "get" + public + "Status"})
+	}
+	if hasStatus && tags.HasVerb("updateStatus") {
+		routes = append(routes, Route{Verb: "updateStatus", Comment: "update status", Method: "PUT", Path: path + "/{name}/status", Handler: "update" + public + "Status"})
+	}
+	extensions := Extensions(c, t, tags)
+	for i, e := range extensions {
+		routes = append(routes, Route{Verb: "extension", Comment: e.Handler, Method: e.HTTPMethod, Path: path + "/{name}" + e.Path, Handler: e.Handler, Extension: &extensions[i]})
+	}
+	return routes
+}
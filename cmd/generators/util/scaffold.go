@@ -0,0 +1,64 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/klog"
+)
+
+// ScaffoldFileType is the file type of the go files which the user edits,
+// they are only written if they do not exist. It must be registered in the
+// context with NewScaffoldFile.
+const ScaffoldFileType = "scaffold"
+
+// scaffoldFile writes go files which do not exist, existing files are only
+// overwritten if force is set.
+type scaffoldFile struct {
+	golang generator.FileType
+	force  bool
+}
+
+// NewScaffoldFile returns the file type of the scaffold files.
+func NewScaffoldFile(force bool) generator.FileType {
+	return &scaffoldFile{golang: generator.NewGolangFile(), force: force}
+}
+
+func (ft *scaffoldFile) AssembleFile(f *generator.File, path string) error {
+	if !ft.force {
+		_, err := os.Stat(path)
+		if err == nil {
+			klog.Infof("keep the existing scaffold file %q", path)
+			return nil
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return ft.golang.AssembleFile(f, path)
+}
+
+// VerifyFile only checks that the file exists, the user owns its content.
+func (ft *scaffoldFile) VerifyFile(f *generator.File, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("scaffold file %q does not exist: %v", path, err)
+	}
+	return nil
+}
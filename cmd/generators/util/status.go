@@ -0,0 +1,37 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/types"
+)
+
+// HasStatus returns true if the status sub-resource should be generated for
+// the type, it requires a "Status" member and no +genclient:noStatus tag.
+func HasStatus(t *types.Type, tags tags.Tags) bool {
+	if tags.NoStatus {
+		return false
+	}
+	for _, m := range t.Members {
+		if m.Name == "Status" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gosoon/code-generator/pkg/tags"
+
+	"k8s.io/gengo/types"
+)
+
+// StructValidation is the validation of the members of a struct type.
+type StructValidation struct {
+	Type    *types.Type
+	Members []MemberValidation
+}
+
+// MemberValidation is the validation of a member of a struct type.
+type MemberValidation struct {
+	// Name is the name of the member.
+	Name string
+	// Type is the type of the member.
+	Type *types.Type
+	// Path is the json name of the member in the paths of the field errors,
+	// it is empty for the embedded structs whose members are promoted.
+	Path string
+	// Validation are the validation tags of the member.
+	tags.Validation
+	// Kind is the kind of the value checked by the tags, "string", "number",
+	// "pointer", "slice", "map" or "interface".
+	Kind string
+	// StringPointer is true for a pointer to a string, the string tags check
+	// the string it points to.
+	StringPointer bool
+	// Struct is the named struct type of a member which is a struct, a
+	// pointer to a struct or a slice of structs or of pointers to structs
+	// with validated members.
+	Struct *types.Type
+	// Elem is "struct", "pointer", "slice" or "pointerSlice", how the member
+	// holds Struct.
+	Elem string
+}
+
+// FieldError is an error found in the tags of a member of a struct type.
+type FieldError struct {
+	// Type is the name of the struct type.
+	Type types.Name
+	// Member is the name of the member.
+	Member string
+	Err    error
+}
+
+// Error returns the error in the "field Type.Member: message" format.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s.%s: %v", e.Type.Name, e.Member, e.Err)
+}
+
+// FieldErrors are the errors found in the tags of the members of struct types.
+type FieldErrors []*FieldError
+
+// Error returns all errors, one per line.
+func (e FieldErrors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validations returns the validation of t, which is first, and of the named
+// struct types of its members which have validated members. The validation
+// tags are checked against the types of the members, the error is the
+// FieldErrors of all the members with invalid tags.
+func Validations(t *types.Type) ([]StructValidation, error) {
+	v := &validations{validated: map[types.Name]bool{}}
+	v.add(t)
+	if len(v.errs) != 0 {
+		return nil, v.errs
+	}
+	ret := v.structs[:1]
+	for _, s := range v.structs[1:] {
+		if len(s.Members) != 0 {
+			ret = append(ret, s)
+		}
+	}
+	return ret, nil
+}
+
+type validations struct {
+	structs []StructValidation
+	// validated is true for the struct types which have validated members.
+	validated map[types.Name]bool
+	errs      FieldErrors
+}
+
+// add adds the validation of the struct type t and of the struct types of
+// its members, it returns false if no member of t is validated. The members
+// with invalid tags are added to the errors and skipped.
+func (v *validations) add(t *types.Type) bool {
+	if validated, ok := v.validated[t.Name]; ok {
+		return validated
+	}
+	// the members of recursive types are validated by the function of the type
+	v.validated[t.Name] = true
+	index := len(v.structs)
+	v.structs = append(v.structs, StructValidation{Type: t})
+
+	var members []MemberValidation
+	for _, m := range t.Members {
+		path, ok := jsonPath(m)
+		if !ok {
+			continue
+		}
+		validation, err := tags.ParseValidationTags(m.CommentLines)
+		if err != nil {
+			v.errs = append(v.errs, &FieldError{Type: t.Name, Member: m.Name, Err: err})
+			continue
+		}
+		member := MemberValidation{Name: m.Name, Type: m.Type, Path: path, Validation: validation}
+		if !validation.IsEmpty() {
+			if err := member.setKind(m.Type); err != nil {
+				v.errs = append(v.errs, &FieldError{Type: t.Name, Member: m.Name, Err: err})
+				continue
+			}
+		}
+
+		elem, s := structOf(m.Type)
+		if s != nil && len(s.Name.Name) != 0 {
+			if v.add(s) {
+				member.Struct, member.Elem = s, elem
+			}
+		}
+		if !validation.IsEmpty() || member.Struct != nil {
+			members = append(members, member)
+		}
+	}
+	v.structs[index].Members = members
+	v.validated[t.Name] = len(members) != 0
+	return len(members) != 0
+}
+
+// setKind sets the kind of the member of type t and checks that the tags of
+// the member are supported by the kind.
+func (member *MemberValidation) setKind(t *types.Type) error {
+	t = underlying(t)
+	switch {
+	case t.Name == types.String.Name:
+		member.Kind = "string"
+	case t.Name == types.Bool.Name:
+		member.Kind = "bool"
+	case t.Kind == types.Builtin:
+		member.Kind = "number"
+	case t.Kind == types.Pointer:
+		member.Kind = "pointer"
+		member.StringPointer = underlying(t.Elem).Name == types.String.Name
+	default:
+		member.Kind = strings.ToLower(string(t.Kind))
+	}
+
+	switch member.Kind {
+	case "string", "number", "pointer", "slice", "map", "interface":
+	default:
+		if member.Required {
+			return fmt.Errorf("+validation:required is not supported for %s fields", member.Kind)
+		}
+	}
+	if member.Kind == "string" || member.StringPointer {
+		return nil
+	}
+	switch {
+	case member.MinLength != 0:
+		return fmt.Errorf("+validation:minLength is only supported for string fields")
+	case len(member.Pattern) != 0:
+		return fmt.Errorf("+validation:pattern is only supported for string fields")
+	case len(member.Enum) != 0:
+		return fmt.Errorf("+validation:enum is only supported for string fields")
+	}
+	return nil
+}
+
+// jsonPath returns the json name of the member, it is empty for the embedded
+// structs whose members are promoted, ok is false for the members which are
+// not encoded or can not be accessed from the generated packages.
+func jsonPath(m types.Member) (name string, ok bool) {
+	if strings.ToLower(m.Name[:1]) == m.Name[:1] {
+		return "", false
+	}
+	name = strings.Split(reflect.StructTag(m.Tags).Get("json"), ",")[0]
+	switch {
+	case name == "-":
+		return "", false
+	case len(name) != 0:
+		return name, true
+	}
+	if elem, s := structOf(m.Type); m.Embedded && s != nil && elem != "slice" && elem != "pointerSlice" {
+		return "", true
+	}
+	return m.Name, true
+}
+
+// Value returns the go expression of the string checked by the string tags of
+// the member of obj, e.g. "string(*obj.Phase)".
+func (member *MemberValidation) Value(obj string) string {
+	value, t := obj+"."+member.Name, member.Type
+	if member.StringPointer {
+		value, t = "*"+value, underlying(t).Elem
+	}
+	if t.Name != types.String.Name {
+		value = "string(" + value + ")"
+	}
+	return value
+}
+
+// underlying returns the type of the aliases.
+func underlying(t *types.Type) *types.Type {
+	for t.Kind == types.Alias {
+		t = t.Underlying
+	}
+	return t
+}
+
+// structOf returns the struct type of a member of type t which is a struct,
+// a pointer to a struct or a slice of structs or of pointers to structs.
+func structOf(t *types.Type) (string, *types.Type) {
+	t = underlying(t)
+	switch {
+	case t.Kind == types.Struct:
+		return "struct", t
+	case t.Kind == types.Pointer && underlying(t.Elem).Kind == types.Struct:
+		return "pointer", t.Elem
+	case t.Kind == types.Slice && underlying(t.Elem).Kind == types.Struct:
+		return "slice", t.Elem
+	case t.Kind == types.Slice && underlying(t.Elem).Kind == types.Pointer && underlying(underlying(t.Elem).Elem).Kind == types.Struct:
+		return "pointerSlice", underlying(t.Elem).Elem
+	}
+	return "", nil
+}
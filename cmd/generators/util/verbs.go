@@ -0,0 +1,28 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"github.com/gosoon/code-generator/pkg/tags"
+)
+
+// HasPatch returns true if the patch route should be generated for the type,
+// the patch is applied to the object returned by the get service method and
+// stored with the update service method, so both verbs are required.
+func HasPatch(tags tags.Tags) bool {
+	return tags.HasVerb("patch") && tags.HasVerb("get") && tags.HasVerb("update")
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"k8s.io/gengo/types"
+)
+
+var (
+	versionRegexp = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+	groupRegexp   = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+)
+
+// GroupVersion is the api group and version which serves the types of a package.
+type GroupVersion struct {
+	// Group is empty for the core group.
+	Group   string
+	Version string
+}
+
+// Prefix returns the route prefix of the group version, "/api/<version>" for
+// the core group, "/apis/<group>/<version>" otherwise.
+func (gv GroupVersion) Prefix() string {
+	if len(gv.Group) == 0 {
+		return "/api/" + gv.Version
+	}
+	return "/apis/" + gv.Group + "/" + gv.Version
+}
+
+// String returns "<group>/<version>", or the version for the core group.
+func (gv GroupVersion) String() string {
+	if len(gv.Group) == 0 {
+		return gv.Version
+	}
+	return gv.Group + "/" + gv.Version
+}
+
+// PackageGroupVersion returns the group version of the package p. The
+// +groupVersion=<group>/<version> or +groupVersion=<version> tag in doc.go
+// sets it, otherwise the version is the last element of the package path if
+// it looks like a version, e.g. "v2", or "v1".
+func PackageGroupVersion(p *types.Package) (GroupVersion, error) {
+	values := types.ExtractCommentTags("+", p.Comments)["groupVersion"]
+	if len(values) == 0 {
+		if version := path.Base(p.Path); versionRegexp.MatchString(version) {
+			return GroupVersion{Version: version}, nil
+		}
+		return GroupVersion{Version: "v1"}, nil
+	}
+	if len(values) > 1 {
+		return GroupVersion{}, fmt.Errorf("multiple +groupVersion tags")
+	}
+	var gv GroupVersion
+	parts := strings.Split(values[0], "/")
+	switch len(parts) {
+	case 1:
+		gv.Version = parts[0]
+	case 2:
+		gv.Group, gv.Version = parts[0], parts[1]
+	default:
+		return GroupVersion{}, fmt.Errorf("invalid +groupVersion %q, expected <group>/<version> or <version>", values[0])
+	}
+	if len(gv.Group) != 0 && !groupRegexp.MatchString(gv.Group) {
+		return GroupVersion{}, fmt.Errorf("invalid group %q in +groupVersion", gv.Group)
+	}
+	if !versionRegexp.MatchString(gv.Version) {
+		return GroupVersion{}, fmt.Errorf("invalid version %q in +groupVersion, expected e.g. v1 or v1beta1", gv.Version)
+	}
+	return gv, nil
+}
+
+// APIPrefix returns the route prefix of the type t, the errors of the tags of
+// the input packages are reported before the code is generated.
+func APIPrefix(u types.Universe, t *types.Type) string {
+	gv, _ := PackageGroupVersion(u.Package(t.Name.Package))
+	return gv.Prefix()
+}
+
+// PathExpr returns the go expression of the route path, the {namespace}
+// parameter is replaced with the go expression namespace.
+func PathExpr(path, namespace string) string {
+	return strings.Replace(fmt.Sprintf("%q", path), "{namespace}", `" + `+namespace+` + "`, -1)
+}
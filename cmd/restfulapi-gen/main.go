@@ -18,6 +18,8 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"os"
 
 	generatorargs "github.com/gosoon/code-generator/cmd/args"
 	"github.com/gosoon/code-generator/cmd/generators"
@@ -45,6 +47,11 @@ func main() {
 		generators.DefaultNameSystem(),
 		generators.Packages,
 	); err != nil {
+		// report the tag errors of the input types without a stack trace
+		if diagnostics, ok := err.(generators.Diagnostics); ok {
+			fmt.Fprintln(os.Stderr, diagnostics)
+			os.Exit(1)
+		}
 		glog.Fatalf("Error: %v", err)
 	}
 	glog.Info("Completed successfully.")
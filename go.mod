@@ -8,4 +8,5 @@ require (
 	k8s.io/code-generator v0.0.0-20190826114438-f795916aae3f
 	k8s.io/gengo v0.0.0-20190826232639-a874a240740c
 	k8s.io/klog v0.4.0
+	sigs.k8s.io/yaml v1.1.0
 )
@@ -29,6 +29,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gosoon/code-generator/pkg/boilerplate"
+
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/namer"
 	"k8s.io/gengo/parser"
@@ -41,9 +43,6 @@ import (
 // before calling AddFlags.
 func Default() *GeneratorArgs {
 	return &GeneratorArgs{
-		OutputBase: DefaultSourceTree(),
-		GoHeaderFilePath: filepath.Join(DefaultSourceTree(),
-			"github.com/gosoon/code-generator/pkg/boilerplate/boilerplate.go.txt"),
 		GeneratedBuildTag: "ignore_autogenerated",
 		//GeneratedByCommentTemplate: "// Code generated by GENERATOR_NAME. DO NOT EDIT.",
 		defaultCommandLineFlags: true,
@@ -55,7 +54,9 @@ type GeneratorArgs struct {
 	// Which directories to parse.
 	InputDirs []string
 
-	// Source tree to write results to.
+	// Source tree to write results to. If empty, the output package is
+	// written to the directory of the module of the working directory, or
+	// to $GOPATH/src outside of a module.
 	OutputBase string
 
 	// Package path within the source tree.
@@ -64,7 +65,8 @@ type GeneratorArgs struct {
 	// Output file name.
 	OutputFileBaseName string
 
-	// Where to get copyright header text.
+	// Where to get copyright header text, the boilerplate of this repo is
+	// used if it is empty.
 	GoHeaderFilePath string
 
 	// If GeneratedByCommentTemplate is set, generate a "Code generated by" comment
@@ -96,19 +98,23 @@ func (g *GeneratorArgs) WithoutDefaultFlagParsing() *GeneratorArgs {
 
 func (g *GeneratorArgs) AddFlags(fs *pflag.FlagSet) {
 	fs.StringSliceVarP(&g.InputDirs, "input-dirs", "i", g.InputDirs, "Comma-separated list of import paths to get input types from.")
-	fs.StringVarP(&g.OutputBase, "output-base", "o", g.OutputBase, "Output base; defaults to $GOPATH/src/ or ./ if $GOPATH is not set.")
+	fs.StringVarP(&g.OutputBase, "output-base", "o", g.OutputBase, "Output base; defaults to the directory of the go.mod of the output package, otherwise $GOPATH/src/ or ./ if $GOPATH is not set.")
 	fs.StringVarP(&g.OutputPackagePath, "output-package", "p", g.OutputPackagePath, "Base package path.")
 	fs.StringVarP(&g.OutputFileBaseName, "output-file-base", "O", g.OutputFileBaseName, "Base name (without .go suffix) for output files.")
-	fs.StringVarP(&g.GoHeaderFilePath, "go-header-file", "h", g.GoHeaderFilePath, "File containing boilerplate header text. The string YEAR will be replaced with the current 4-digit year.")
+	fs.StringVarP(&g.GoHeaderFilePath, "go-header-file", "h", g.GoHeaderFilePath, "File containing boilerplate header text, defaults to the built-in boilerplate. The string YEAR will be replaced with the current 4-digit year.")
 	fs.BoolVar(&g.VerifyOnly, "verify-only", g.VerifyOnly, "If true, only verify existing output, do not write anything.")
 	fs.StringVar(&g.GeneratedBuildTag, "build-tag", g.GeneratedBuildTag, "A Go build tag to use to identify files generated by this command. Should be unique.")
 }
 
 // LoadGoBoilerplate loads the boilerplate file passed to --go-header-file.
 func (g *GeneratorArgs) LoadGoBoilerplate() ([]byte, error) {
-	b, err := ioutil.ReadFile(g.GoHeaderFilePath)
-	if err != nil {
-		return nil, err
+	b := []byte(boilerplate.Header)
+	if len(g.GoHeaderFilePath) != 0 {
+		var err error
+		b, err = ioutil.ReadFile(g.GoHeaderFilePath)
+		if err != nil {
+			return nil, err
+		}
 	}
 	b = bytes.Replace(b, []byte("YEAR"), []byte(strconv.Itoa(time.Now().Year())), -1)
 
@@ -173,13 +179,19 @@ func DefaultSourceTree() string {
 // Execute implements main().
 // If you don't need any non-default behavior, use as:
 // args.Default().Execute(...)
-func (g *GeneratorArgs) Execute(nameSystems namer.NameSystems, defaultSystem string, pkgs func(*generator.Context, *GeneratorArgs) generator.Packages) error {
+// The error returned by pkgs is returned as is, so callers can report it
+// without the "Failed" prefix of the other errors.
+func (g *GeneratorArgs) Execute(nameSystems namer.NameSystems, defaultSystem string, pkgs func(*generator.Context, *GeneratorArgs) (generator.Packages, error)) error {
 	if g.defaultCommandLineFlags {
 		g.AddFlags(pflag.CommandLine)
 		pflag.CommandLine.AddGoFlagSet(goflag.CommandLine)
 		pflag.Parse()
 	}
 
+	if err := g.resolveInputDirs(); err != nil {
+		return fmt.Errorf("Failed resolving input dirs: %v", err)
+	}
+
 	b, err := g.NewBuilder()
 	if err != nil {
 		return fmt.Errorf("Failed making a parser: %v", err)
@@ -191,9 +203,16 @@ func (g *GeneratorArgs) Execute(nameSystems namer.NameSystems, defaultSystem str
 	}
 
 	c.Verify = g.VerifyOnly
-	packages := pkgs(c, g)
+	packages, err := pkgs(c, g)
+	if err != nil {
+		return err
+	}
 
-	if err := c.ExecutePackages(g.OutputBase, packages); err != nil {
+	outputBase, packages, err := g.outputPackages(packages)
+	if err != nil {
+		return fmt.Errorf("Failed mapping the output package: %v", err)
+	}
+	if err := c.ExecutePackages(outputBase, packages); err != nil {
 		return fmt.Errorf("Failed executing generator: %v", err)
 	}
 
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package args
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/gengo/generator"
+)
+
+// module is a go module found from a go.mod file.
+type module struct {
+	// Dir is the directory of the go.mod file.
+	Dir string
+	// Path is the module path of the module directive.
+	Path string
+}
+
+// findModule returns the module containing dir, or nil if dir is not in a module.
+func findModule(dir string) (*module, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		gomod := filepath.Join(dir, "go.mod")
+		data, err := ioutil.ReadFile(gomod)
+		if err == nil {
+			path := modulePath(data)
+			if len(path) == 0 {
+				return nil, fmt.Errorf("no module directive in %s", gomod)
+			}
+			return &module{Dir: dir, Path: path}, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// modulePath returns the module path of the module directive of a go.mod file.
+func modulePath(gomod []byte) string {
+	for _, line := range strings.Split(string(gomod), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], "\"`")
+		}
+	}
+	return ""
+}
+
+// importPath returns the import path of the directory dir of the module.
+func (m *module) importPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(m.Dir, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("%s is not in module %s", dir, m.Path)
+	}
+	if rel == "." {
+		return m.Path, nil
+	}
+	return m.Path + "/" + filepath.ToSlash(rel), nil
+}
+
+// relPath returns the path of the package pkg relative to the module
+// directory, ok is false if pkg is not in the module.
+func (m *module) relPath(pkg string) (path string, ok bool) {
+	if pkg == m.Path {
+		return "", true
+	}
+	if strings.HasPrefix(pkg, m.Path+"/") {
+		return strings.TrimPrefix(pkg, m.Path+"/"), true
+	}
+	return "", false
+}
+
+// isLocalDir checks if the input dir is a file system path instead of an
+// import path, e.g. "./pkg/types".
+func isLocalDir(dir string) bool {
+	return dir == "." || dir == ".." || strings.HasPrefix(dir, "./") ||
+		strings.HasPrefix(dir, "../") || filepath.IsAbs(dir)
+}
+
+// resolveInputDirs replaces the local input dirs with the import paths of
+// their module, so the generated code imports the input packages.
+func (g *GeneratorArgs) resolveInputDirs() error {
+	for i, dir := range g.InputDirs {
+		recursive := strings.HasSuffix(dir, "/...")
+		dir = strings.TrimSuffix(dir, "/...")
+		if !isLocalDir(dir) {
+			continue
+		}
+		m, err := findModule(dir)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			return fmt.Errorf("input dir %q is not in a go module", dir)
+		}
+		path, err := m.importPath(dir)
+		if err != nil {
+			return err
+		}
+		if recursive {
+			path += "/..."
+		}
+		g.InputDirs[i] = path
+	}
+	return nil
+}
+
+// modulePackage writes a package relative to the directory of its module.
+type modulePackage struct {
+	generator.Package
+	path string
+}
+
+func (p *modulePackage) Path() string { return p.path }
+
+// outputPackages returns the output base and the packages to write. Without
+// --output-base, packages of the module of the working directory are written
+// to the module directory, otherwise to $GOPATH/src.
+func (g *GeneratorArgs) outputPackages(packages generator.Packages) (string, generator.Packages, error) {
+	if len(g.OutputBase) != 0 {
+		return g.OutputBase, packages, nil
+	}
+	m, err := findModule(".")
+	if err != nil {
+		return "", nil, err
+	}
+	if m == nil {
+		return DefaultSourceTree(), packages, nil
+	}
+	if _, ok := m.relPath(g.OutputPackagePath); !ok {
+		return DefaultSourceTree(), packages, nil
+	}
+
+	ret := make(generator.Packages, 0, len(packages))
+	for _, p := range packages {
+		path, ok := m.relPath(p.Path())
+		if !ok {
+			return "", nil, fmt.Errorf("package %s is not in module %s", p.Path(), m.Path)
+		}
+		ret = append(ret, &modulePackage{Package: p, path: path})
+	}
+	return m.Dir, ret, nil
+}
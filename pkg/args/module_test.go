@@ -0,0 +1,94 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package args
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/gengo/generator"
+)
+
+func TestModulePath(t *testing.T) {
+	cases := map[string]string{
+		"module github.com/a/b\n\ngo 1.12\n":         "github.com/a/b",
+		"// comment\nmodule \"github.com/a/b\" // c": "github.com/a/b",
+		"go 1.12\n": "",
+	}
+	for gomod, expected := range cases {
+		if path := modulePath([]byte(gomod)); path != expected {
+			t.Errorf("expected %q for %q, got %q", expected, gomod, path)
+		}
+	}
+}
+
+func TestResolveModule(t *testing.T) {
+	dir, err := ioutil.TempDir("", "module")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.MkdirAll(filepath.Join(dir, "pkg/types"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/a/b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(filepath.Join(dir, "pkg")); err != nil {
+		t.Fatal(err)
+	}
+
+	g := &GeneratorArgs{
+		InputDirs:         []string{"./types", "../pkg/...", "github.com/c/d"},
+		OutputPackagePath: "github.com/a/b/gen",
+	}
+	if err := g.resolveInputDirs(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"github.com/a/b/pkg/types", "github.com/a/b/pkg/...", "github.com/c/d"}
+	for i := range expected {
+		if g.InputDirs[i] != expected[i] {
+			t.Errorf("expected input dir %q, got %q", expected[i], g.InputDirs[i])
+		}
+	}
+
+	base, packages, err := g.outputPackages(generator.Packages{
+		&generator.DefaultPackage{PackagePath: "github.com/a/b/gen/server"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	realDir, _ := filepath.EvalSymlinks(dir)
+	if realBase, _ := filepath.EvalSymlinks(base); realBase != realDir {
+		t.Errorf("expected output base %q, got %q", dir, base)
+	}
+	if path := packages[0].Path(); path != "gen/server" {
+		t.Errorf("expected package path %q, got %q", "gen/server", path)
+	}
+
+	g.OutputPackagePath = "github.com/c/d"
+	if base, _, _ := g.outputPackages(nil); base != DefaultSourceTree() {
+		t.Errorf("expected output base %q outside of the module, got %q", DefaultSourceTree(), base)
+	}
+}
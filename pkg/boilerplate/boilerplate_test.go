@@ -0,0 +1,34 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boilerplate
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+// TestHeader checks that Header is kept in sync with boilerplate.go.txt, the
+// file can not be embedded with the go version of go.mod.
+func TestHeader(t *testing.T) {
+	data, err := ioutil.ReadFile("boilerplate.go.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Header != string(data) {
+		t.Errorf("Header differs from boilerplate.go.txt:\n%s", data)
+	}
+}
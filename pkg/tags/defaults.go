@@ -0,0 +1,40 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tags
+
+import (
+	"errors"
+)
+
+// defaultTag is the tag of the default value of a field.
+const defaultTag = "default"
+
+// ParseDefaultTag parses the +default tag of a field, e.g. "+default=3" or
+// "+default=["a","b"]". It returns the value of the tag, ok is false if the
+// field has no +default tag.
+func ParseDefaultTag(lines []string) (value string, ok bool, err error) {
+	values := ExtractCommentTags("+", lines)[defaultTag]
+	switch {
+	case len(values) == 0:
+		return "", false, nil
+	case len(values) > 1:
+		return "", false, errors.New("multiple +default tags")
+	case len(values[0]) == 0:
+		return "", false, errors.New("empty +default tag, expected +default=<value>")
+	}
+	return values[0], true, nil
+}
@@ -25,12 +25,20 @@ import (
 var supportedTags = []string{
 	"genclient",
 	"genclient:verbs",
+	"genclient:nonNamespaced",
+	"genclient:noStatus",
+	"genclient:noVerbs",
+	"genclient:onlyVerbs",
+	"genclient:skipVerbs",
+	"genclient:readonly",
+	"genclient:method",
 }
 
 // SupportedVerbs is a list of supported verbs for +onlyVerbs and +skipVerbs.
 var SupportedVerbs = []string{
 	"create",
 	"update",
+	"updateStatus",
 	"delete",
 	"get",
 	"list",
@@ -55,6 +63,7 @@ var unsupportedExtensionVerbs = []string{
 	"deleteCollection",
 	"watch",
 	"delete",
+	"list",
 }
 
 // inputTypeSupportedVerbs is a list of verb types that supports overriding the
@@ -155,6 +164,9 @@ type Tags struct {
 // HasVerb returns true if we should include the given verb in final client interface and
 // generate the function for it.
 func (t Tags) HasVerb(verb string) bool {
+	if t.NoVerbs {
+		return false
+	}
 	if len(t.SkipVerbs) == 0 {
 		return true
 	}
@@ -187,6 +199,54 @@ func ParseClientGenTags(lines []string) (Tags, error) {
 	if len(value) > 0 && len(value[0]) > 0 {
 		return ret, fmt.Errorf("+genclient=%s is invalid, use //+genclient if you want to generate client or omit it when you want to disable generation", value)
 	}
+	_, ret.NonNamespaced = values[genClientPrefix+"nonNamespaced"]
+	// Check the old format and error when used
+	if value := values["nonNamespaced"]; len(value) > 0 && len(value[0]) > 0 {
+		return ret, fmt.Errorf("+nonNamespaced=%s is invalid, use //+genclient:nonNamespaced instead", value[0])
+	}
+	_, ret.NoVerbs = values[genClientPrefix+"noVerbs"]
+	_, ret.NoStatus = values[genClientPrefix+"noStatus"]
+	onlyVerbs := []string{}
+	if _, isReadonly := values[genClientPrefix+"readonly"]; isReadonly {
+		onlyVerbs = ReadonlyVerbs
+	}
+	if v, exists := values[genClientPrefix+"skipVerbs"]; exists {
+		ret.SkipVerbs = strings.Split(v[0], ",")
+		if err := validateVerbs("skipVerbs", ret.SkipVerbs); err != nil {
+			return ret, err
+		}
+	}
+	if v, exists := values[genClientPrefix+"onlyVerbs"]; exists || len(onlyVerbs) > 0 {
+		if len(v) > 0 {
+			verbs := strings.Split(v[0], ",")
+			if err := validateVerbs("onlyVerbs", verbs); err != nil {
+				return ret, err
+			}
+			onlyVerbs = append(onlyVerbs, verbs...)
+		}
+		// Check for conflicts
+		for _, s := range ret.SkipVerbs {
+			for _, o := range onlyVerbs {
+				if s == o {
+					return ret, fmt.Errorf("verb %q used both in genclient:skipVerbs and genclient:onlyVerbs", s)
+				}
+			}
+		}
+		skipVerbs := []string{}
+		for _, m := range SupportedVerbs {
+			skip := true
+			for _, o := range onlyVerbs {
+				if o == m {
+					skip = false
+					break
+				}
+			}
+			if skip {
+				skipVerbs = append(skipVerbs, m)
+			}
+		}
+		ret.SkipVerbs = skipVerbs
+	}
 
 	var err error
 	if ret.Extensions, err = parseClientExtensions(values); err != nil {
@@ -265,6 +325,9 @@ func parseClientExtensions(tags map[string][]string) ([]extension, error) {
 					return nil, fmt.Errorf("%s: input type is not supported for %q verbs (supported verbs: %#v)", ext.VerbName, ext.VerbType, inputTypeSupportedVerbs)
 				}
 			}
+			if err := validateVerbs("method", []string{ext.VerbType}); err != nil {
+				return nil, err
+			}
 			for _, t := range unsupportedExtensionVerbs {
 				if ext.VerbType == t {
 					return nil, fmt.Errorf("verb %q is not supported by extension generator", ext.VerbType)
@@ -276,6 +339,23 @@ func parseClientExtensions(tags map[string][]string) ([]extension, error) {
 	return ret, nil
 }
 
+// validateVerbs checks that every verb listed in the given tag is supported.
+func validateVerbs(tag string, verbs []string) error {
+	for _, verb := range verbs {
+		supported := false
+		for _, v := range SupportedVerbs {
+			if verb == v {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf("unknown verb %q in genclient:%s (supported verbs: %#v)", verb, tag, SupportedVerbs)
+		}
+	}
+	return nil
+}
+
 // validateTags validates that only supported genclient tags were provided.
 func validateClientGenTags(values map[string][]string) error {
 	for _, k := range supportedTags {
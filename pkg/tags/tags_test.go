@@ -0,0 +1,245 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseClientGenTags(t *testing.T) {
+	testCases := map[string]struct {
+		lines       []string
+		expectTags  Tags
+		expectError bool
+	}{
+		"genclient": {
+			lines:      []string{`+genclient`},
+			expectTags: Tags{GenerateClient: true},
+		},
+		"genclient:nonNamespaced": {
+			lines:      []string{`+genclient`, `+genclient:nonNamespaced`},
+			expectTags: Tags{GenerateClient: true, NonNamespaced: true},
+		},
+		"nonNamespaced=true": {
+			lines:       []string{`+genclient`, `+nonNamespaced=true`},
+			expectError: true,
+		},
+		"genclient:noVerbs": {
+			lines:      []string{`+genclient`, `+genclient:noVerbs`},
+			expectTags: Tags{GenerateClient: true, NoVerbs: true},
+		},
+		"genclient:noStatus": {
+			lines:      []string{`+genclient`, `+genclient:noStatus`},
+			expectTags: Tags{GenerateClient: true, NoStatus: true},
+		},
+		"genclient:skipVerbs": {
+			lines:      []string{`+genclient`, `+genclient:skipVerbs=update,delete`},
+			expectTags: Tags{GenerateClient: true, SkipVerbs: []string{"update", "delete"}},
+		},
+		"genclient:onlyVerbs": {
+			lines:      []string{`+genclient`, `+genclient:onlyVerbs=create,delete`},
+			expectTags: Tags{GenerateClient: true, SkipVerbs: []string{"update", "updateStatus", "get", "list", "watch", "patch"}},
+		},
+		"genclient:readonly": {
+			lines:      []string{`+genclient`, `+genclient:readonly`},
+			expectTags: Tags{GenerateClient: true, SkipVerbs: []string{"create", "update", "updateStatus", "delete", "patch"}},
+		},
+		"genclient:conflict": {
+			lines:       []string{`+genclient`, `+genclient:onlyVerbs=create`, `+genclient:skipVerbs=create`},
+			expectError: true,
+		},
+		"genclient:unknownVerb": {
+			lines:       []string{`+genclient`, `+genclient:skipVerbs=remove`},
+			expectError: true,
+		},
+		"genclient:method": {
+			lines: []string{`+genclient`, `+genclient:method=UpdateScale,verb=update,subresource=scale,input=Scale,result=Scale`},
+			expectTags: Tags{GenerateClient: true, Extensions: []extension{{
+				VerbName:           "UpdateScale",
+				VerbType:           "update",
+				SubResourcePath:    "scale",
+				InputTypeOverride:  "Scale",
+				ResultTypeOverride: "Scale",
+			}}},
+		},
+		"genclient:method unsupported verb": {
+			lines:       []string{`+genclient`, `+genclient:method=ListScale,verb=list`},
+			expectError: true,
+		},
+		"genclient:method unknown verb": {
+			lines:       []string{`+genclient`, `+genclient:method=Scale,verb=scale`},
+			expectError: true,
+		},
+		"genclient:invalid": {
+			lines:       []string{`+genclient`, `+genclient:invalid`},
+			expectError: true,
+		},
+	}
+	for key, c := range testCases {
+		result, err := ParseClientGenTags(c.lines)
+		if err != nil && !c.expectError {
+			t.Fatalf("%s: unexpected error: %v", key, err)
+		}
+		if err == nil && c.expectError {
+			t.Fatalf("%s: expected error, got none", key)
+		}
+		if !c.expectError && !reflect.DeepEqual(result, c.expectTags) {
+			t.Errorf("%s: [%v] expected:\n%#v\ngot:\n%#v\n", key, c.lines, c.expectTags, result)
+		}
+	}
+}
+
+func TestHasVerb(t *testing.T) {
+	testCases := map[string]struct {
+		tags   Tags
+		verb   string
+		expect bool
+	}{
+		"all verbs":     {tags: Tags{}, verb: "get", expect: true},
+		"skipped verb":  {tags: Tags{SkipVerbs: []string{"get"}}, verb: "get", expect: false},
+		"other verb":    {tags: Tags{SkipVerbs: []string{"get"}}, verb: "create", expect: true},
+		"no verbs":      {tags: Tags{NoVerbs: true}, verb: "get", expect: false},
+		"no verbs skip": {tags: Tags{NoVerbs: true, SkipVerbs: []string{"get"}}, verb: "create", expect: false},
+	}
+	for key, c := range testCases {
+		if got := c.tags.HasVerb(c.verb); got != c.expect {
+			t.Errorf("%s: HasVerb(%q) expected %v, got %v", key, c.verb, c.expect, got)
+		}
+	}
+}
+
+func TestParseValidationTags(t *testing.T) {
+	testCases := map[string]struct {
+		lines       []string
+		expect      Validation
+		expectError bool
+	}{
+		"no tags": {
+			lines:  []string{`Name of the object.`},
+			expect: Validation{},
+		},
+		"validation:required": {
+			lines:  []string{`+validation:required`},
+			expect: Validation{Required: true},
+		},
+		"validation:required=true": {
+			lines:       []string{`+validation:required=true`},
+			expectError: true,
+		},
+		"validation:minLength": {
+			lines:  []string{`+validation:minLength=3`},
+			expect: Validation{MinLength: 3},
+		},
+		"validation:minLength negative": {
+			lines:       []string{`+validation:minLength=-1`},
+			expectError: true,
+		},
+		"validation:minLength not a number": {
+			lines:       []string{`+validation:minLength=three`},
+			expectError: true,
+		},
+		"validation:pattern": {
+			lines:  []string{`+validation:pattern=^[a-z]+(=[0-9]+)?$`},
+			expect: Validation{Pattern: `^[a-z]+(=[0-9]+)?$`},
+		},
+		"validation:pattern invalid": {
+			lines:       []string{`+validation:pattern=[a-z`},
+			expectError: true,
+		},
+		"validation:enum": {
+			lines:  []string{`+validation:enum=Always,Never`},
+			expect: Validation{Enum: []string{"Always", "Never"}},
+		},
+		"validation:enum empty value": {
+			lines:       []string{`+validation:enum=Always,`},
+			expectError: true,
+		},
+		"multiple tags": {
+			lines:  []string{`+validation:required`, `+validation:minLength=1`, `+validation:enum=a,b`},
+			expect: Validation{Required: true, MinLength: 1, Enum: []string{"a", "b"}},
+		},
+		"duplicate tag": {
+			lines:       []string{`+validation:minLength=1`, `+validation:minLength=2`},
+			expectError: true,
+		},
+		"validation:invalid": {
+			lines:       []string{`+validation:maxLength=3`},
+			expectError: true,
+		},
+	}
+	for key, c := range testCases {
+		result, err := ParseValidationTags(c.lines)
+		if err != nil && !c.expectError {
+			t.Fatalf("%s: unexpected error: %v", key, err)
+		}
+		if err == nil && c.expectError {
+			t.Fatalf("%s: expected error, got none", key)
+		}
+		if !c.expectError && !reflect.DeepEqual(result, c.expect) {
+			t.Errorf("%s: [%v] expected:\n%#v\ngot:\n%#v\n", key, c.lines, c.expect, result)
+		}
+	}
+}
+
+func TestParseDefaultTag(t *testing.T) {
+	testCases := map[string]struct {
+		lines       []string
+		expect      string
+		expectOK    bool
+		expectError bool
+	}{
+		"no tag": {
+			lines: []string{`Replicas of the object.`},
+		},
+		"default": {
+			lines:    []string{`Replicas of the object.`, `+default=3`},
+			expect:   "3",
+			expectOK: true,
+		},
+		"default with equal signs": {
+			lines:    []string{`+default=a=b`},
+			expect:   "a=b",
+			expectOK: true,
+		},
+		"default slice": {
+			lines:    []string{`+default=["a","b"]`},
+			expect:   `["a","b"]`,
+			expectOK: true,
+		},
+		"empty default": {
+			lines:       []string{`+default`},
+			expectError: true,
+		},
+		"duplicate default": {
+			lines:       []string{`+default=1`, `+default=2`},
+			expectError: true,
+		},
+	}
+	for key, c := range testCases {
+		result, ok, err := ParseDefaultTag(c.lines)
+		if err != nil && !c.expectError {
+			t.Fatalf("%s: unexpected error: %v", key, err)
+		}
+		if err == nil && c.expectError {
+			t.Fatalf("%s: expected error, got none", key)
+		}
+		if !c.expectError && (result != c.expect || ok != c.expectOK) {
+			t.Errorf("%s: [%v] expected %q, %v, got %q, %v", key, c.lines, c.expect, c.expectOK, result, ok)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2019 gosoon.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// validationPrefix is the prefix of the field validation tags.
+const validationPrefix = "validation:"
+
+var supportedValidationTags = []string{
+	"validation:required",
+	"validation:minLength",
+	"validation:pattern",
+	"validation:enum",
+}
+
+// Validation represents the validation tags of a field.
+//
+// Example:
+//
+//	type Spec struct {
+//		// +validation:required
+//		// +validation:minLength=3
+//		// +validation:pattern=^[a-z0-9-]+$
+//		Name string
+//		// +validation:enum=Always,Never
+//		Policy string
+//	}
+type Validation struct {
+	// +validation:required
+	Required bool
+	// +validation:minLength=3
+	MinLength int
+	// +validation:pattern=^[a-z]+$
+	Pattern string
+	// +validation:enum=a,b
+	Enum []string
+}
+
+// IsEmpty returns true if the field has no validation tags.
+func (v Validation) IsEmpty() bool {
+	return !v.Required && v.MinLength == 0 && len(v.Pattern) == 0 && len(v.Enum) == 0
+}
+
+// ParseValidationTags parses the provided validation tags of a field and
+// validates that no unknown validation tags are provided.
+func ParseValidationTags(lines []string) (Validation, error) {
+	ret := Validation{}
+	values := ExtractCommentTags("+", lines)
+	for _, tag := range supportedValidationTags {
+		if len(values[tag]) > 1 {
+			return ret, fmt.Errorf("multiple +%s tags", tag)
+		}
+	}
+	if v, exists := values[validationPrefix+"required"]; exists {
+		if len(v[0]) > 0 {
+			return ret, fmt.Errorf("+validation:required=%s is invalid, use +validation:required", v[0])
+		}
+		ret.Required = true
+	}
+	if v, exists := values[validationPrefix+"minLength"]; exists {
+		minLength, err := strconv.Atoi(v[0])
+		if err != nil || minLength < 0 {
+			return ret, fmt.Errorf("invalid +validation:minLength=%s, expected a non-negative integer", v[0])
+		}
+		ret.MinLength = minLength
+	}
+	if v, exists := values[validationPrefix+"pattern"]; exists {
+		if len(v[0]) == 0 {
+			return ret, errors.New("empty +validation:pattern")
+		}
+		if _, err := regexp.Compile(v[0]); err != nil {
+			return ret, fmt.Errorf("invalid +validation:pattern=%s: %v", v[0], err)
+		}
+		ret.Pattern = v[0]
+	}
+	if v, exists := values[validationPrefix+"enum"]; exists {
+		for _, value := range strings.Split(v[0], ",") {
+			if len(value) == 0 {
+				return ret, fmt.Errorf("empty value in +validation:enum=%s", v[0])
+			}
+			ret.Enum = append(ret.Enum, value)
+		}
+	}
+	return ret, validateValidationTags(values)
+}
+
+// validateValidationTags validates that only supported validation tags were provided.
+func validateValidationTags(values map[string][]string) error {
+	for _, k := range supportedValidationTags {
+		delete(values, k)
+	}
+	for key := range values {
+		if strings.HasPrefix(key, validationPrefix) || key == strings.TrimSuffix(validationPrefix, ":") {
+			return errors.New("unknown tag detected: " + key)
+		}
+	}
+	return nil
+}